@@ -2,20 +2,29 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"wacoregateway/internal/cache"
 	"wacoregateway/internal/handler"
+	"wacoregateway/internal/health"
+	"wacoregateway/internal/metrics"
 	"wacoregateway/internal/provider"
+	"wacoregateway/internal/provider/amqpx"
 	"wacoregateway/internal/provider/messaging"
 	"wacoregateway/internal/service"
 	"wacoregateway/model/constant"
+	proto "wacoregateway/model/pb"
 	"wacoregateway/util"
 
 	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func Run(cfg *util.Config) {
@@ -24,27 +33,130 @@ func Run(cfg *util.Config) {
 	logger := provider.NewLogger()
 	validate := validator.New()
 
-	container, err := provider.SqlStoreContainer()
+	container, db, err := provider.SqlStoreContainer()
 	if err != nil {
 		logger.Errorfctx(provider.AppLog, ctx, false, "Failed to connect to database:", err)
 	}
+	shutdownTracing, err := provider.NewTracerProvider(ctx)
+	if err != nil {
+		logger.Errorfctx(provider.AppLog, ctx, false, "Failed to create tracer provider, spans will not be exported: %v", err)
+		shutdownTracing = func(ctx context.Context) error { return nil }
+	}
+
 	conn, err := provider.NewAMQPConn()
+	amqpConnected := err == nil
 	if err != nil {
 		logger.Errorfctx(provider.AppLog, ctx, false, "Failed to connect to AMQP:", err)
 	}
 	publisher := messaging.NewAMQPPublisher(conn)
+
+	var media *service.MediaDownloader
+	if util.Configuration.Media.Download.Enabled {
+		store, err := provider.NewBlobStore()
+		if err != nil {
+			logger.Errorfctx(provider.AppLog, ctx, false, "Failed to create media store, inbound media will not be downloaded: %v", err)
+		} else {
+			media = service.NewMediaDownloader(store, logger)
+		}
+	}
 	logger.Infofctx(provider.AppLog, ctx, "Application started")
 
-	go func(logger provider.ILogger) {
-		service := service.NewService(container, logger, publisher)
-		if err := service.LoadClients(ctx, container); err != nil {
-			logger.Errorfctx(provider.AppLog, ctx, false, "Failed to load new clients: %v", err)
+	pollTTL := time.Duration(util.Configuration.Polls.CacheTTL) * time.Hour
+	if pollTTL <= 0 {
+		pollTTL = 7 * 24 * time.Hour
+	}
+
+	clients := cache.NewClientRegistry()
+	svc := service.NewService(container, clients, logger, publisher, media, service.NewMediaLoader(),
+		cache.NewPollCache(pollTTL), cache.NewLiveLocationCache(), cache.NewSentMessageCache(service.RevokeWindow), cache.NewMediaHandleCache())
+
+	var amqpConn amqpx.ChannelReader
+	if amqpConnected {
+		amqpConn = conn
+	}
+	checker := health.NewChecker(db, amqpConn, clients, proto.WaCoreGateway_ServiceDesc.ServiceName)
+	healthCtx, stopHealth := context.WithCancel(ctx)
+	defer stopHealth()
+
+	interval := time.Duration(util.Configuration.Health.Interval) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	go checker.Run(healthCtx, interval)
+	if util.Configuration.Health.Enabled {
+		go func() {
+			addr := fmt.Sprintf(":%v", util.Configuration.Health.Port)
+			logger.Infofctx(provider.AppLog, ctx, "Health server listening on %v", addr)
+			if err := checker.ListenAndServe(healthCtx, addr); err != nil {
+				logger.Errorfctx(provider.AppLog, ctx, false, "failed to serve health: %v", err)
+			}
+		}()
+	}
+
+	metrics.RegisterConnectedDevices(func() int {
+		connected := 0
+		for _, client := range clients.Clients() {
+			if client.IsConnected() && client.IsLoggedIn() {
+				connected++
+			}
 		}
+		return connected
+	})
+	if util.Configuration.Metrics.Enabled {
+		go func() {
+			addr := fmt.Sprintf(":%v", util.Configuration.Metrics.Port)
+			logger.Infofctx(provider.AppLog, ctx, "Metrics server listening on %v", addr)
+			if err := metrics.ListenAndServe(healthCtx, addr); err != nil {
+				logger.Errorfctx(provider.AppLog, ctx, false, "failed to serve metrics: %v", err)
+			}
+		}()
+	}
 
-		app := handler.NewApp(validate, logger, container, service)
-		server, err := app.GRPCServer()
+	var (
+		serverOptions []grpc.ServerOption
+		tlsConfig     *tls.Config
+	)
+	if util.Configuration.Server.TLS.Enabled {
+		certs, err := provider.NewCertReloader()
 		if err != nil {
-			logger.Errorfctx(provider.AppLog, ctx, false, "Failed to create gRPC server: %v", err)
+			logger.Errorfctx(provider.AppLog, ctx, false, "Failed to load TLS files: %v", err)
+			return
+		}
+		go func() {
+			if err := certs.Watch(healthCtx, logger); err != nil {
+				logger.Errorfctx(provider.AppLog, ctx, false, "Failed to watch TLS files, certificates will not be reloaded: %v", err)
+			}
+		}()
+		tlsConfig = certs.TLSConfig()
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	app := handler.NewApp(validate, logger, container, svc, checker)
+	server, err := app.GRPCServer(serverOptions...)
+	if err != nil {
+		logger.Errorfctx(provider.AppLog, ctx, false, "Failed to create gRPC server: %v", err)
+		return
+	}
+
+	var gateway *handler.Gateway
+	if util.Configuration.Gateway.Enabled {
+		gateway, err = app.Gateway()
+		if err != nil {
+			logger.Errorfctx(provider.AppLog, ctx, false, "Failed to create HTTP gateway: %v", err)
+			return
+		}
+		go func() {
+			addr := fmt.Sprintf(":%v", util.Configuration.Gateway.Port)
+			logger.Infofctx(provider.AppLog, ctx, "HTTP gateway listening on %v", addr)
+			if err := gateway.ListenAndServe(addr, tlsConfig); err != nil {
+				logger.Errorfctx(provider.AppLog, ctx, false, "failed to serve HTTP gateway: %v", err)
+			}
+		}()
+	}
+
+	go func(logger provider.ILogger) {
+		if err := svc.LoadClients(ctx, container); err != nil {
+			logger.Errorfctx(provider.AppLog, ctx, false, "Failed to load new clients: %v", err)
 		}
 
 		addr := fmt.Sprintf(":%v", util.Configuration.Server.Port)
@@ -64,9 +176,81 @@ func Run(cfg *util.Config) {
 	sig := <-shutdownCh
 	logger.Infofctx(provider.AppLog, ctx, "Receiving signal: %s", sig)
 
-	func(logger provider.ILogger) {
-		defer container.Close()
+	timeout := time.Duration(util.Configuration.Server.ShutdownTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
 
-		logger.Infofctx(provider.AppLog, ctx, "Successfully stop Application.")
-	}(logger)
+	// Probes and health checking clients see the gateway is going away before it stops accepting RPCs
+	checker.Shutdown()
+
+	// In-flight RPCs, including message sends, are allowed to finish before the server is stopped forcibly
+	shutdownStep(ctx, logger, "stop gRPC server", timeout, func(stepCtx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			return nil
+		case <-stepCtx.Done():
+			server.Stop()
+			return stepCtx.Err()
+		}
+	})
+	// In-flight HTTP requests finish before the clients they use are disconnected
+	shutdownStep(ctx, logger, "stop HTTP gateway", timeout, func(stepCtx context.Context) error {
+		if gateway == nil {
+			return nil
+		}
+		return gateway.Shutdown(stepCtx)
+	})
+	// Clients are disconnected before draining, so no new events are produced while the pending ones are flushed
+	shutdownStep(ctx, logger, "disconnect WhatsApp clients", timeout, func(stepCtx context.Context) error {
+		svc.DisconnectAll(stepCtx)
+		return nil
+	})
+	shutdownStep(ctx, logger, "flush pending events", timeout, svc.Drain)
+	shutdownStep(ctx, logger, "close AMQP connection", timeout, func(stepCtx context.Context) error {
+		if !amqpConnected {
+			return nil
+		}
+		return conn.Close()
+	})
+	shutdownStep(ctx, logger, "flush traces", timeout, shutdownTracing)
+	shutdownStep(ctx, logger, "close database", timeout, func(stepCtx context.Context) error {
+		if container == nil {
+			return nil
+		}
+		return container.Close()
+	})
+
+	stopHealth()
+
+	logger.Infofctx(provider.AppLog, ctx, "Successfully stop Application.")
+}
+
+// shutdownStep runs one step of the graceful shutdown, moving on to the next step after timeout
+func shutdownStep(ctx context.Context, logger provider.ILogger, name string, timeout time.Duration, step func(ctx context.Context) error) {
+	logger.Infofctx(provider.AppLog, ctx, "Shutdown: %s", name)
+
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- step(stepCtx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			logger.Errorfctx(provider.AppLog, ctx, false, "Shutdown: %s failed: %v", name, err)
+			return
+		}
+		logger.Infofctx(provider.AppLog, ctx, "Shutdown: %s done", name)
+	case <-stepCtx.Done():
+		logger.Errorfctx(provider.AppLog, ctx, false, "Shutdown: %s timed out after %s", name, timeout)
+	}
 }
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+
+	"wacoregateway/internal/cache"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AllSenders grants a credential every sender JID
+const AllSenders = "*"
+
+// Principal is an authenticated caller and the sender JIDs it may use
+type Principal struct {
+	Name    string
+	Tenant  string
+	all     bool
+	senders map[string]struct{}
+}
+
+// NewPrincipal creates a Principal allowed to use senderJIDs, which may be JIDs, phone numbers or AllSenders
+func NewPrincipal(name, tenant string, senderJIDs ...string) *Principal {
+	p := &Principal{Name: name, Tenant: tenant, senders: make(map[string]struct{})}
+	p.allow(senderJIDs...)
+	return p
+}
+
+func (p *Principal) allow(senderJIDs ...string) {
+	for _, senderJID := range senderJIDs {
+		if senderJID == AllSenders {
+			p.all = true
+			continue
+		}
+		if user := cache.JIDUser(senderJID); user != "" {
+			p.senders[user] = struct{}{}
+		}
+	}
+}
+
+// Allows reports whether the principal may use senderJID, matched on the phone number
+// so device JIDs, bare JIDs and phone numbers of the same account are treated alike
+func (p *Principal) Allows(senderJID string) bool {
+	if p.all {
+		return true
+	}
+	user := cache.JIDUser(senderJID)
+	if user == "" {
+		return false
+	}
+	_, ok := p.senders[user]
+	return ok
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal of ctx, nil when authentication is disabled
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// Authorize returns a PermissionDenied error when the caller of ctx may not use senderJID
+func Authorize(ctx context.Context, senderJID string) error {
+	p := FromContext(ctx)
+	if p == nil || p.Allows(senderJID) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%s is not allowed to use sender %s", p.Name, senderJID)
+}
+
+// Allowed reports whether the caller of ctx may use senderJID
+func Allowed(ctx context.Context, senderJID string) bool {
+	p := FromContext(ctx)
+	return p == nil || p.Allows(senderJID)
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPrincipalAllows(t *testing.T) {
+	p := NewPrincipal("backend", "", "6281111@s.whatsapp.net", "+6282222", "6283333:12@s.whatsapp.net")
+
+	tests := []struct {
+		senderJID string
+		want      bool
+	}{
+		{"6281111@s.whatsapp.net", true},
+		{"6281111:3@s.whatsapp.net", true},
+		{"6281111", true},
+		{"+6281111", true},
+		{"6282222@s.whatsapp.net", true},
+		{"6283333", true},
+		{"6284444@s.whatsapp.net", false},
+		{"", false},
+		{"not a jid@", false},
+	}
+	for _, tt := range tests {
+		if got := p.Allows(tt.senderJID); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.senderJID, got, tt.want)
+		}
+	}
+}
+
+func TestPrincipalAllowsAll(t *testing.T) {
+	p := NewPrincipal("admin", "", AllSenders)
+	if !p.Allows("6284444@s.whatsapp.net") {
+		t.Error("principal with all senders should allow any sender")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	if err := Authorize(context.Background(), "6284444"); err != nil {
+		t.Errorf("without principal Authorize should allow, got %v", err)
+	}
+
+	ctx := WithPrincipal(context.Background(), NewPrincipal("backend", "", "6281111"))
+	if err := Authorize(ctx, "6281111@s.whatsapp.net"); err != nil {
+		t.Errorf("Authorize allowed sender: %v", err)
+	}
+	if code := status.Code(Authorize(ctx, "6284444")); code != codes.PermissionDenied {
+		t.Errorf("Authorize other sender code = %v, want %v", code, codes.PermissionDenied)
+	}
+	if Allowed(ctx, "6284444") {
+		t.Error("Allowed other sender = true, want false")
+	}
+}
@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"wacoregateway/internal/provider/grpcx"
+	"wacoregateway/util"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	APIKeyHeader        = "x-api-key"
+	AuthorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+// minAPIKeyLength is the minimum length of a configured API key, it also refuses placeholders like change-me
+const minAPIKeyLength = 32
+
+// publicMethodPrefixes are served without credentials
+var publicMethodPrefixes = []string{"/grpc.health.v1.Health/"}
+
+// jwtClaims are the claims of a bearer token, the tenant and the sender JIDs are both optional
+type jwtClaims struct {
+	Tenant     string   `json:"tenant"`
+	SenderJIDs []string `json:"sender_jids"`
+	jwt.RegisteredClaims
+}
+
+// Authenticator authenticates gRPC calls with a static API key, a JWT bearer token or a client certificate
+type Authenticator struct {
+	apiKeys    map[[sha256.Size]byte]*Principal
+	identities map[string]*Principal
+	tenants    map[string][]string
+	jwtSecret  []byte
+	parser     *jwt.Parser
+}
+
+// NewAuthenticator creates an Authenticator from the client identities of the TLS configuration and,
+// only when auth is enabled, the API keys and JWT settings of the auth configuration
+func NewAuthenticator() (*Authenticator, error) {
+	cfg := util.Configuration.Auth
+
+	a := &Authenticator{
+		apiKeys:    make(map[[sha256.Size]byte]*Principal),
+		identities: make(map[string]*Principal),
+		tenants:    cfg.Tenants,
+	}
+	if cfg.Enabled {
+		if err := a.loadCredentials(); err != nil {
+			return nil, err
+		}
+	}
+	for i, identity := range util.Configuration.Server.TLS.ClientIdentities {
+		if identity.Identity == "" {
+			return nil, fmt.Errorf("client identity %d has no identity", i)
+		}
+		if identity.Tenant != "" {
+			if _, ok := a.tenants[identity.Tenant]; !ok {
+				return nil, fmt.Errorf("client identity %s refers to unknown tenant %s", identity.Identity, identity.Tenant)
+			}
+		}
+		a.identities[identity.Identity] = a.principal(identity.Identity, identity.Tenant, identity.SenderJIDs)
+	}
+
+	return a, nil
+}
+
+// loadCredentials loads the API keys and the JWT settings of the auth configuration
+func (a *Authenticator) loadCredentials() error {
+	cfg := util.Configuration.Auth
+
+	a.jwtSecret = []byte(cfg.JWT.Secret)
+	for i, apiKey := range cfg.APIKeys {
+		if len(apiKey.Key) < minAPIKeyLength || strings.HasPrefix(apiKey.Key, "<") {
+			return fmt.Errorf("api key %d is empty, a placeholder or shorter than %d characters", i, minAPIKeyLength)
+		}
+		if apiKey.Tenant != "" {
+			if _, ok := a.tenants[apiKey.Tenant]; !ok {
+				return fmt.Errorf("api key %s refers to unknown tenant %s", apiKey.Name, apiKey.Tenant)
+			}
+		}
+		hash := sha256.Sum256([]byte(apiKey.Key))
+		if _, exists := a.apiKeys[hash]; exists {
+			return fmt.Errorf("api key %s is configured twice", apiKey.Name)
+		}
+		a.apiKeys[hash] = a.principal(apiKey.Name, apiKey.Tenant, apiKey.SenderJIDs)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.JWT.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWT.Issuer))
+	}
+	if cfg.JWT.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWT.Audience))
+	}
+	a.parser = jwt.NewParser(options...)
+
+	return nil
+}
+
+// principal creates a Principal allowed to use the sender JIDs of tenant and senderJIDs
+func (a *Authenticator) principal(name, tenant string, senderJIDs []string) *Principal {
+	p := NewPrincipal(name, tenant, senderJIDs...)
+	p.allow(a.tenants[tenant]...)
+	return p
+}
+
+// Authenticate returns the principal of the credentials in the incoming metadata of ctx
+func (a *Authenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(APIKeyHeader); len(values) > 0 {
+		// The keys are looked up by hash, so the lookup time does not depend on how much of a key matches
+		if p, ok := a.apiKeys[sha256.Sum256([]byte(values[0]))]; ok {
+			return p, nil
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid api key")
+	}
+
+	if values := md.Get(AuthorizationHeader); len(values) > 0 {
+		if len(values[0]) <= len(bearerPrefix) || !strings.EqualFold(values[0][:len(bearerPrefix)], bearerPrefix) {
+			return nil, status.Errorf(codes.Unauthenticated, "authorization must be a bearer token")
+		}
+		return a.authenticateJWT(values[0][len(bearerPrefix):])
+	}
+
+	if cert := peerCertificate(ctx); cert != nil {
+		for _, identity := range certIdentities(cert) {
+			if p, ok := a.identities[identity]; ok {
+				return p, nil
+			}
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "client certificate %s is not allowed", cert.Subject.CommonName)
+	}
+
+	return nil, status.Errorf(codes.Unauthenticated, "missing %s or %s bearer token", APIKeyHeader, AuthorizationHeader)
+}
+
+// peerCertificate returns the verified client certificate of the call, nil without mutual TLS
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
+
+// certIdentities returns the common name and the subject alternative names of cert
+func certIdentities(cert *x509.Certificate) []string {
+	identities := []string{}
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	identities = append(identities, cert.DNSNames...)
+	identities = append(identities, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+func (a *Authenticator) authenticateJWT(token string) (*Principal, error) {
+	if len(a.jwtSecret) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "bearer tokens are not accepted")
+	}
+
+	var claims jwtClaims
+	_, err := a.parser.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return a.jwtSecret, nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid bearer token: %v", err)
+	}
+
+	name := claims.Subject
+	if name == "" {
+		name = "jwt"
+	}
+	return a.principal(name, claims.Tenant, claims.SenderJIDs), nil
+}
+
+func isPublicMethod(method string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryServerInterceptor rejects unauthenticated unary calls and puts the principal in the context
+func (a *Authenticator) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	p, err := a.Authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(WithPrincipal(ctx, p), req)
+}
+
+// StreamServerInterceptor rejects unauthenticated streaming calls and puts the principal in the context
+func (a *Authenticator) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	p, err := a.Authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, grpcx.WithContext(ss, WithPrincipal(ss.Context(), p)))
+}
@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"strings"
+	"testing"
+	"time"
+
+	"wacoregateway/util"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testAPIKey    = "k3y-0123456789abcdef0123456789abcdef"
+	testJWTSecret = "jwt-secret"
+)
+
+// configure sets the auth configuration for one test
+func configure(t *testing.T, enabled bool) {
+	t.Helper()
+	saved := util.Configuration
+	t.Cleanup(func() { util.Configuration = saved })
+
+	cfg := &util.Configuration
+	cfg.Auth.Enabled = enabled
+	cfg.Auth.Tenants = map[string][]string{"acme": {"6281111"}, "all": {AllSenders}}
+	cfg.Auth.APIKeys = []struct {
+		Name       string   `mapstructure:"name"`
+		Key        string   `mapstructure:"key"`
+		Tenant     string   `mapstructure:"tenant"`
+		SenderJIDs []string `mapstructure:"sender_jids"`
+	}{{Name: "backend", Key: testAPIKey, Tenant: "acme", SenderJIDs: []string{"6282222"}}}
+	cfg.Auth.JWT.Secret = testJWTSecret
+	cfg.Auth.JWT.Issuer = "issuer"
+	cfg.Auth.JWT.Audience = ""
+	cfg.Server.TLS.ClientIdentities = []struct {
+		Identity   string   `mapstructure:"identity"`
+		Tenant     string   `mapstructure:"tenant"`
+		SenderJIDs []string `mapstructure:"sender_jids"`
+	}{{Identity: "backend.internal", SenderJIDs: []string{"6283333"}}}
+}
+
+func incoming(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+func token(t *testing.T, method jwt.SigningMethod, secret string, claims jwtClaims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	configure(t, true)
+	a, err := NewAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := a.Authenticate(incoming(APIKeyHeader, testAPIKey))
+	if err != nil {
+		t.Fatalf("valid api key: %v", err)
+	}
+	if p.Name != "backend" || !p.Allows("6281111") || !p.Allows("6282222") || p.Allows("6283333") {
+		t.Errorf("api key principal %+v has the wrong senders", p)
+	}
+
+	if _, err := a.Authenticate(incoming(APIKeyHeader, "wrong")); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong api key code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+	if _, err := a.Authenticate(context.Background()); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("missing credentials code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestAuthenticateJWT(t *testing.T) {
+	configure(t, true)
+	a, err := NewAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := jwtClaims{
+		Tenant:     "acme",
+		SenderJIDs: []string{"6284444"},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "crm",
+			Issuer:    "issuer",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	noExpiry := valid
+	noExpiry.ExpiresAt = nil
+	wrongIssuer := valid
+	wrongIssuer.Issuer = "other"
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, valid).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		ok     bool
+	}{
+		{"valid", "Bearer " + token(t, jwt.SigningMethodHS256, testJWTSecret, valid), true},
+		{"lowercase scheme", "bearer " + token(t, jwt.SigningMethodHS256, testJWTSecret, valid), true},
+		{"wrong secret", "Bearer " + token(t, jwt.SigningMethodHS256, "other", valid), false},
+		{"expired", "Bearer " + token(t, jwt.SigningMethodHS256, testJWTSecret, expired), false},
+		{"no expiry", "Bearer " + token(t, jwt.SigningMethodHS256, testJWTSecret, noExpiry), false},
+		{"wrong issuer", "Bearer " + token(t, jwt.SigningMethodHS256, testJWTSecret, wrongIssuer), false},
+		{"none algorithm", "Bearer " + unsigned, false},
+		{"basic scheme", "Basic dXNlcjpwYXNz", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := a.Authenticate(incoming(AuthorizationHeader, tt.header))
+			if !tt.ok {
+				if status.Code(err) != codes.Unauthenticated {
+					t.Errorf("code = %v, want %v", status.Code(err), codes.Unauthenticated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Name != "crm" || !p.Allows("6281111") || !p.Allows("6284444") || p.Allows("6282222") {
+				t.Errorf("jwt principal %+v has the wrong senders", p)
+			}
+		})
+	}
+}
+
+func TestAuthenticateClientCertificate(t *testing.T) {
+	configure(t, false)
+	a, err := NewAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withCert := func(cert *x509.Certificate) context.Context {
+		state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+	}
+
+	p, err := a.Authenticate(withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "client"}, DNSNames: []string{"backend.internal"}}))
+	if err != nil {
+		t.Fatalf("known identity: %v", err)
+	}
+	if !p.Allows("6283333") || p.Allows("6281111") {
+		t.Errorf("certificate principal %+v has the wrong senders", p)
+	}
+
+	if _, err := a.Authenticate(withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "unknown"}})); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("unknown identity code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestCredentialsIgnoredWhenAuthDisabled(t *testing.T) {
+	configure(t, false)
+	a, err := NewAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.Authenticate(incoming(APIKeyHeader, testAPIKey)); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("api key with auth disabled code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+	valid := jwtClaims{RegisteredClaims: jwt.RegisteredClaims{Issuer: "issuer", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}}
+	header := "Bearer " + token(t, jwt.SigningMethodHS256, testJWTSecret, valid)
+	if _, err := a.Authenticate(incoming(AuthorizationHeader, header)); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("jwt with auth disabled code = %v, want %v", status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestNewAuthenticatorRejectsWeakKeys(t *testing.T) {
+	for _, key := range []string{"", "change-me", strings.Repeat("a", minAPIKeyLength-1), "<random secret of at least 32 characters>"} {
+		configure(t, true)
+		util.Configuration.Auth.APIKeys[0].Key = key
+		if _, err := NewAuthenticator(); err == nil {
+			t.Errorf("api key %q was accepted", key)
+		}
+	}
+}
+
+func TestNewAuthenticatorRejectsUnknownTenant(t *testing.T) {
+	configure(t, true)
+	util.Configuration.Auth.APIKeys[0].Tenant = "unknown"
+	if _, err := NewAuthenticator(); err == nil {
+		t.Error("api key with unknown tenant was accepted")
+	}
+}
@@ -0,0 +1,278 @@
+package cache
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Device connection states tracked by the ClientRegistry
+const (
+	DeviceStatePairing         = "pairing"
+	DeviceStateConnecting      = "connecting"
+	DeviceStateConnected       = "connected"
+	DeviceStateDisconnected    = "disconnected"
+	DeviceStateReconnecting    = "reconnecting"
+	DeviceStateReconnectFailed = "reconnect_failed"
+	DeviceStateLoggedOut       = "logged_out"
+	DeviceStateFailed          = "failed"
+)
+
+// DeviceStatus holds the last known connection state of a device
+type DeviceStatus struct {
+	State              string
+	PushName           string
+	Platform           string
+	BusinessName       string
+	LastConnectedAt    time.Time
+	LastDisconnectedAt time.Time
+	LastError          string
+	ReconnectAttempts  int
+	NextReconnectAt    time.Time
+}
+
+// StateChange is sent to watchers when the state of a device changes
+type StateChange struct {
+	JID    string
+	From   string
+	To     string
+	Status DeviceStatus
+}
+
+type registryEntry struct {
+	client *whatsmeow.Client
+	status DeviceStatus
+}
+
+// ClientRegistry holds the whatsmeow clients and their status by device key, it is safe for concurrent use.
+// A device can have a status without a client, e.g. when it failed to connect on startup.
+type ClientRegistry struct {
+	mu          sync.RWMutex
+	entries     map[string]*registryEntry
+	watchers    map[int]chan StateChange
+	nextWatcher int
+}
+
+func NewClientRegistry() *ClientRegistry {
+	return &ClientRegistry{
+		entries:  make(map[string]*registryEntry),
+		watchers: make(map[int]chan StateChange),
+	}
+}
+
+// Get returns the client stored under key, or nil
+func (r *ClientRegistry) Get(key string) *whatsmeow.Client {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if entry, exists := r.entries[key]; exists {
+		return entry.client
+	}
+	return nil
+}
+
+// Resolve finds a client by its key, full device JID, bare JID or phone number
+// and returns it together with its key
+func (r *ClientRegistry) Resolve(id string) (string, *whatsmeow.Client) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if entry, exists := r.entries[id]; exists && entry.client != nil {
+		return id, entry.client
+	}
+
+	user := JIDUser(id)
+	if user == "" {
+		return "", nil
+	}
+	for _, key := range r.sortedKeys() {
+		if entry := r.entries[key]; entry.client != nil && JIDUser(key) == user {
+			return key, entry.client
+		}
+	}
+	return "", nil
+}
+
+// Set stores client under key, its status is kept
+func (r *ClientRegistry) Set(key string, client *whatsmeow.Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entry(key).client = client
+}
+
+// Add stores client under key unless a client is already stored there, it reports whether it was stored
+func (r *ClientRegistry) Add(key string, client *whatsmeow.Client) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry := r.entry(key)
+	if entry.client != nil {
+		return false
+	}
+	entry.client = client
+	return true
+}
+
+// Delete disconnects and removes a client and its status
+func (r *ClientRegistry) Delete(key string) bool {
+	r.mu.Lock()
+	entry, exists := r.entries[key]
+	delete(r.entries, key)
+	r.mu.Unlock()
+
+	if !exists {
+		return false
+	}
+	if entry.client != nil && entry.client.IsConnected() {
+		entry.client.Disconnect()
+	}
+	return true
+}
+
+// Remove disconnects and removes the entry under key only while it still holds client
+func (r *ClientRegistry) Remove(key string, client *whatsmeow.Client) bool {
+	r.mu.Lock()
+	entry, exists := r.entries[key]
+	if !exists || entry.client != client {
+		r.mu.Unlock()
+		return false
+	}
+	delete(r.entries, key)
+	r.mu.Unlock()
+
+	if client != nil && client.IsConnected() {
+		client.Disconnect()
+	}
+	return true
+}
+
+// Rekey moves client and its status to a new key, e.g. from its pairing name to the device JID once paired.
+// Nothing is moved when the entry under oldKey no longer holds client.
+func (r *ClientRegistry) Rekey(oldKey, newKey string, client *whatsmeow.Client) {
+	r.mu.Lock()
+	entry, exists := r.entries[oldKey]
+	if !exists || entry.client != client || oldKey == newKey {
+		r.mu.Unlock()
+		return
+	}
+	stale := r.entries[newKey]
+	delete(r.entries, oldKey)
+	r.entries[newKey] = entry
+	r.mu.Unlock()
+
+	if stale != nil && stale.client != nil && stale.client != entry.client && stale.client.IsConnected() {
+		stale.client.Disconnect()
+	}
+}
+
+// Clients returns a snapshot of all stored clients by key
+func (r *ClientRegistry) Clients() map[string]*whatsmeow.Client {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	clients := make(map[string]*whatsmeow.Client, len(r.entries))
+	for key, entry := range r.entries {
+		if entry.client != nil {
+			clients[key] = entry.client
+		}
+	}
+	return clients
+}
+
+// Count returns the number of stored clients
+func (r *ClientRegistry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	count := 0
+	for _, entry := range r.entries {
+		if entry.client != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Status returns a copy of the status of a device, or nil if it is not tracked
+func (r *ClientRegistry) Status(key string) *DeviceStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, exists := r.entries[key]
+	if !exists {
+		return nil
+	}
+	status := entry.status
+	return &status
+}
+
+// UpdateStatus applies update to the status of a device, tracking it when it is unknown.
+// Watchers are notified when the update changes the state.
+func (r *ClientRegistry) UpdateStatus(key string, update func(status *DeviceStatus)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry := r.entry(key)
+	from := entry.status.State
+	update(&entry.status)
+	if entry.status.State != from {
+		r.notify(StateChange{JID: key, From: from, To: entry.status.State, Status: entry.status})
+	}
+}
+
+// Watch returns a channel receiving every state change and a function to stop watching.
+// Changes are dropped for a watcher whose buffer is full.
+func (r *ClientRegistry) Watch(buffer int) (<-chan StateChange, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := r.nextWatcher
+	r.nextWatcher++
+	ch := make(chan StateChange, buffer)
+	r.watchers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(r.watchers, id)
+			close(ch)
+		})
+	}
+}
+
+func (r *ClientRegistry) entry(key string) *registryEntry {
+	entry, exists := r.entries[key]
+	if !exists {
+		entry = &registryEntry{}
+		r.entries[key] = entry
+	}
+	return entry
+}
+
+func (r *ClientRegistry) notify(change StateChange) {
+	for _, ch := range r.watchers {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+}
+
+func (r *ClientRegistry) sortedKeys() []string {
+	keys := make([]string, 0, len(r.entries))
+	for key := range r.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// JIDUser returns the phone number part of a device JID, bare JID or phone number
+func JIDUser(id string) string {
+	if !strings.Contains(id, "@") {
+		return strings.TrimPrefix(strings.TrimSpace(id), "+")
+	}
+	jid, err := types.ParseJID(id)
+	if err != nil {
+		return ""
+	}
+	return jid.User
+}
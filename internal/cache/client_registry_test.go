@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+)
+
+func TestClientRegistryResolve(t *testing.T) {
+	registry := NewClientRegistry()
+	client := &whatsmeow.Client{}
+	registry.Set("6281111:12@s.whatsapp.net", client)
+
+	for _, id := range []string{"6281111:12@s.whatsapp.net", "6281111@s.whatsapp.net", "6281111", "+6281111"} {
+		key, got := registry.Resolve(id)
+		if got != client || key != "6281111:12@s.whatsapp.net" {
+			t.Errorf("Resolve(%q) = %q, %p, want the stored client", id, key, got)
+		}
+	}
+	if key, got := registry.Resolve("6282222"); got != nil || key != "" {
+		t.Errorf("Resolve unknown = %q, %p, want nothing", key, got)
+	}
+}
+
+func TestClientRegistryRekeyAndRemove(t *testing.T) {
+	registry := NewClientRegistry()
+	client := &whatsmeow.Client{}
+	if !registry.Add("budi", client) {
+		t.Fatal("Add under a free key = false, want true")
+	}
+	registry.UpdateStatus("budi", func(status *DeviceStatus) { status.State = DeviceStatePairing })
+
+	// A second pairing under the same name is refused, a Rekey of another client moves nothing
+	if registry.Add("budi", &whatsmeow.Client{}) {
+		t.Error("Add under a taken key = true, want false")
+	}
+	registry.Rekey("budi", "6281111:12@s.whatsapp.net", &whatsmeow.Client{})
+	if registry.Get("budi") != client {
+		t.Fatal("Rekey of another client moved the entry")
+	}
+
+	registry.Rekey("budi", "6281111:12@s.whatsapp.net", client)
+	if registry.Get("budi") != nil {
+		t.Error("client is still stored under its pairing name after Rekey")
+	}
+	if registry.Get("6281111:12@s.whatsapp.net") != client {
+		t.Error("client is not stored under its device JID after Rekey")
+	}
+	if status := registry.Status("6281111:12@s.whatsapp.net"); status == nil || status.State != DeviceStatePairing {
+		t.Errorf("status after Rekey = %+v, want state %s", status, DeviceStatePairing)
+	}
+
+	if registry.Remove("6281111:12@s.whatsapp.net", &whatsmeow.Client{}) {
+		t.Error("Remove with another client = true, want false")
+	}
+	if !registry.Remove("6281111:12@s.whatsapp.net", client) {
+		t.Error("Remove with the stored client = false, want true")
+	}
+	if registry.Count() != 0 {
+		t.Errorf("Count after Remove = %d, want 0", registry.Count())
+	}
+}
+
+func TestClientRegistryConcurrentUse(t *testing.T) {
+	registry := NewClientRegistry()
+	changes, stop := registry.Watch(16)
+	defer stop()
+	go func() {
+		for range changes {
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("device-%d", i)
+			jid := fmt.Sprintf("62811%d:1@s.whatsapp.net", i)
+			for j := 0; j < 100; j++ {
+				client := &whatsmeow.Client{}
+				registry.Set(name, client)
+				registry.UpdateStatus(name, func(status *DeviceStatus) { status.State = DeviceStatePairing })
+				registry.Resolve(fmt.Sprintf("62811%d", i))
+				registry.Rekey(name, jid, client)
+				registry.UpdateStatus(jid, func(status *DeviceStatus) { status.State = DeviceStateConnected })
+				registry.Status(jid)
+				registry.Clients()
+				registry.Delete(jid)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if registry.Count() != 0 {
+		t.Errorf("Count = %d, want 0", registry.Count())
+	}
+}
+
+func TestClientRegistryWatch(t *testing.T) {
+	registry := NewClientRegistry()
+	changes, stop := registry.Watch(4)
+
+	registry.UpdateStatus("6281111", func(status *DeviceStatus) { status.State = DeviceStateConnecting })
+	registry.UpdateStatus("6281111", func(status *DeviceStatus) { status.PushName = "Budi" })
+	registry.UpdateStatus("6281111", func(status *DeviceStatus) { status.State = DeviceStateConnected })
+
+	for _, want := range []StateChange{
+		{JID: "6281111", From: "", To: DeviceStateConnecting},
+		{JID: "6281111", From: DeviceStateConnecting, To: DeviceStateConnected},
+	} {
+		select {
+		case got := <-changes:
+			if got.JID != want.JID || got.From != want.From || got.To != want.To {
+				t.Errorf("change = %+v, want %+v", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no change delivered, want %+v", want)
+		}
+	}
+	select {
+	case got := <-changes:
+		t.Errorf("unexpected change %+v, updates keeping the state are not delivered", got)
+	default:
+	}
+
+	stop()
+	stop()
+	if _, open := <-changes; open {
+		t.Error("channel is still open after stop")
+	}
+	// Changes after stop must not panic on the closed channel
+	registry.UpdateStatus("6281111", func(status *DeviceStatus) { status.State = DeviceStateDisconnected })
+}
+
+func TestClientRegistryWatchDropsWhenFull(t *testing.T) {
+	registry := NewClientRegistry()
+	changes, stop := registry.Watch(1)
+	defer stop()
+
+	registry.UpdateStatus("6281111", func(status *DeviceStatus) { status.State = DeviceStateConnecting })
+	registry.UpdateStatus("6281111", func(status *DeviceStatus) { status.State = DeviceStateConnected })
+
+	if got := <-changes; got.To != DeviceStateConnecting {
+		t.Errorf("first change to %s, want %s", got.To, DeviceStateConnecting)
+	}
+	select {
+	case got := <-changes:
+		t.Errorf("change %+v was delivered to a full watcher", got)
+	default:
+	}
+}
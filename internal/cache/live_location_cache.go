@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// LiveLocationShare holds the state of an active live location share
+type LiveLocationShare struct {
+	SenderJID string
+	To        string
+	StartedAt time.Time
+	Duration  time.Duration
+	Sequence  int64
+}
+
+// ExpiresAt returns the time the share stops accepting updates
+func (l *LiveLocationShare) ExpiresAt() time.Time {
+	return l.StartedAt.Add(l.Duration)
+}
+
+// LiveLocationCache holds the active live location shares by their initial message ID
+type LiveLocationCache struct {
+	mu     sync.Mutex
+	shares map[string]*LiveLocationShare
+}
+
+func NewLiveLocationCache() *LiveLocationCache {
+	return &LiveLocationCache{
+		shares: make(map[string]*LiveLocationShare),
+	}
+}
+
+// Set stores a live location share by its initial message ID
+func (c *LiveLocationCache) Set(shareID string, share *LiveLocationShare) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shares[shareID] = share
+}
+
+// Get returns a copy of the live location share, or nil if it does not exist
+func (c *LiveLocationCache) Get(shareID string) *LiveLocationShare {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	share, exists := c.shares[shareID]
+	if !exists {
+		return nil
+	}
+	copied := *share
+	return &copied
+}
+
+// NextSequence increments and returns the sequence number of a share
+func (c *LiveLocationCache) NextSequence(shareID string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	share, exists := c.shares[shareID]
+	if !exists {
+		return 0, false
+	}
+	share.Sequence++
+	return share.Sequence, true
+}
+
+// Delete removes a live location share from the cache
+func (c *LiveLocationCache) Delete(shareID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.shares, shareID)
+}
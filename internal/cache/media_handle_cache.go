@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+)
+
+// MediaHandle is media already uploaded to WhatsApp that can be sent again without re-uploading
+type MediaHandle struct {
+	SenderJID string
+	MediaType string
+	MimeType  string
+	FileName  string
+	Width     uint32
+	Height    uint32
+	Animated  bool
+	Upload    whatsmeow.UploadResponse
+	ExpiresAt time.Time
+}
+
+// MediaHandleCache holds the media handles of uploaded media until they expire
+type MediaHandleCache struct {
+	mu      sync.RWMutex
+	handles map[string]*MediaHandle
+}
+
+func NewMediaHandleCache() *MediaHandleCache {
+	return &MediaHandleCache{
+		handles: make(map[string]*MediaHandle),
+	}
+}
+
+// Set stores a media handle until its ExpiresAt
+func (c *MediaHandleCache) Set(handle string, media *MediaHandle) {
+	c.mu.Lock()
+	c.handles[handle] = media
+	c.mu.Unlock()
+
+	time.AfterFunc(time.Until(media.ExpiresAt), func() {
+		c.Delete(handle)
+	})
+}
+
+// Get returns the media handle, or nil if it is unknown or expired
+func (c *MediaHandleCache) Get(handle string) *MediaHandle {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.handles[handle]
+}
+
+// Delete removes a media handle from the cache
+func (c *MediaHandleCache) Delete(handle string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.handles, handle)
+}
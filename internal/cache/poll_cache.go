@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// PollCache keeps the option names of recent polls by message ID, poll votes only carry their hashes.
+// It is held in memory only, votes for polls created before a restart or older than the ttl cannot
+// be resolved and are published with their unknown_hashes.
+type PollCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	polls map[string]pollEntry
+}
+
+type pollEntry struct {
+	options   []string
+	expiresAt time.Time
+}
+
+func NewPollCache(ttl time.Duration) *PollCache {
+	return &PollCache{
+		ttl:   ttl,
+		polls: make(map[string]pollEntry),
+	}
+}
+
+// Set stores the option names of a poll and forgets them after the ttl
+func (c *PollCache) Set(pollID string, options []string) {
+	c.mu.Lock()
+	c.polls[pollID] = pollEntry{options: options, expiresAt: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	time.AfterFunc(c.ttl, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		// The poll may have been stored again since, only its latest expiry counts
+		if entry, exists := c.polls[pollID]; exists && !time.Now().Before(entry.expiresAt) {
+			delete(c.polls, pollID)
+		}
+	})
+}
+
+// Get returns the option names of a poll, or nil if the poll is unknown
+func (c *PollCache) Get(pollID string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.polls[pollID].options
+}
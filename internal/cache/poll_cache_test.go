@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPollCacheExpires(t *testing.T) {
+	polls := NewPollCache(20 * time.Millisecond)
+	polls.Set("poll", []string{"yes", "no"})
+
+	if got := polls.Get("poll"); !slices.Equal(got, []string{"yes", "no"}) {
+		t.Fatalf("Get = %v, want [yes no]", got)
+	}
+	if got := polls.Get("unknown"); got != nil {
+		t.Errorf("Get unknown poll = %v, want nil", got)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if got := polls.Get("poll"); got != nil {
+		t.Errorf("Get after ttl = %v, want nil", got)
+	}
+}
+
+func TestPollCacheSetAgainExtendsTTL(t *testing.T) {
+	polls := NewPollCache(40 * time.Millisecond)
+	polls.Set("poll", []string{"yes"})
+	time.Sleep(25 * time.Millisecond)
+	polls.Set("poll", []string{"no"})
+
+	// The timer of the first Set fires here and must keep the newer entry
+	time.Sleep(25 * time.Millisecond)
+	if got := polls.Get("poll"); !slices.Equal(got, []string{"no"}) {
+		t.Errorf("Get = %v, want [no]", got)
+	}
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// SentMessage holds what is known about a message sent through the gateway
+type SentMessage struct {
+	SenderJID string
+	Chat      string
+	SentAt    time.Time
+}
+
+// SentMessageCache holds the messages sent through the gateway by their ID for a limited time
+type SentMessageCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	messages map[string]*SentMessage
+}
+
+func NewSentMessageCache(ttl time.Duration) *SentMessageCache {
+	return &SentMessageCache{
+		ttl:      ttl,
+		messages: make(map[string]*SentMessage),
+	}
+}
+
+// Set stores a sent message by its ID and forgets it after the ttl
+func (c *SentMessageCache) Set(messageID string, message *SentMessage) {
+	c.mu.Lock()
+	c.messages[messageID] = message
+	c.mu.Unlock()
+
+	time.AfterFunc(c.ttl, func() {
+		c.Delete(messageID)
+	})
+}
+
+// Get returns a copy of the sent message, or nil if it is unknown
+func (c *SentMessageCache) Get(messageID string) *SentMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	message, exists := c.messages[messageID]
+	if !exists {
+		return nil
+	}
+	copied := *message
+	return &copied
+}
+
+// Delete removes a sent message from the cache
+func (c *SentMessageCache) Delete(messageID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.messages, messageID)
+}
@@ -1,13 +1,21 @@
 package handler
 
 import (
+	"fmt"
+
+	"wacoregateway/internal/auth"
+	"wacoregateway/internal/health"
+	"wacoregateway/internal/metrics"
 	"wacoregateway/internal/provider"
 	"wacoregateway/internal/service"
 	proto "wacoregateway/model/pb"
+	"wacoregateway/util"
 
 	"github.com/go-playground/validator/v10"
 	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type App struct {
@@ -15,6 +23,7 @@ type App struct {
 	log       provider.ILogger
 	container *sqlstore.Container
 	service   service.ServiceInterface
+	health    *health.Checker
 }
 
 type server struct {
@@ -23,16 +32,57 @@ type server struct {
 	service   service.ServiceInterface
 }
 
-func NewApp(validate *validator.Validate, log provider.ILogger, container *sqlstore.Container, service service.ServiceInterface) *App {
-	return &App{validate: validate, log: log, container: container, service: service}
+func NewApp(validate *validator.Validate, log provider.ILogger, container *sqlstore.Container, service service.ServiceInterface, health *health.Checker) *App {
+	return &App{validate: validate, log: log, container: container, service: service, health: health}
 }
 
-func (a *App) GRPCServer() (*grpc.Server, error) {
-	grpcServer := grpc.NewServer()
+// GRPCServer creates the gRPC server, options like the transport credentials are passed to grpc.NewServer
+func (a *App) GRPCServer(options ...grpc.ServerOption) (*grpc.Server, error) {
+	unary := []grpc.UnaryServerInterceptor{UnaryRequestIDInterceptor, metrics.UnaryServerInterceptor}
+	stream := []grpc.StreamServerInterceptor{StreamRequestIDInterceptor, metrics.StreamServerInterceptor}
+	authenticator, err := newAuthenticator()
+	if err != nil {
+		return nil, err
+	}
+	if authenticator != nil {
+		unary = append(unary, authenticator.UnaryServerInterceptor)
+		stream = append(stream, authenticator.StreamServerInterceptor)
+	}
+
+	grpcServer := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}, options...)...)
 	proto.RegisterWaCoreGatewayServer(grpcServer, &server{
 		service:   a.service,
 		container: a.container,
 	})
+	healthpb.RegisterHealthServer(grpcServer, a.health.GRPCServer())
 
 	return grpcServer, nil
 }
+
+// Gateway creates the REST/JSON gateway, it serves the same handlers as the gRPC server
+func (a *App) Gateway() (*Gateway, error) {
+	authenticator, err := newAuthenticator()
+	if err != nil {
+		return nil, err
+	}
+	return newGateway(&server{
+		service:   a.service,
+		container: a.container,
+	}, authenticator)
+}
+
+// newAuthenticator returns nil when neither auth nor client certificate identities are configured
+func newAuthenticator() (*auth.Authenticator, error) {
+	if !util.Configuration.Auth.Enabled && len(util.Configuration.Server.TLS.ClientIdentities) == 0 {
+		return nil, nil
+	}
+	authenticator, err := auth.NewAuthenticator()
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth configuration: %w", err)
+	}
+	return authenticator, nil
+}
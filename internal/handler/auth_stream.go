@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"wacoregateway/internal/auth"
+	proto "wacoregateway/model/pb"
+)
+
+// authorizedLiveLocationStream authorizes the sender of the first update, which binds the stream to its share
+type authorizedLiveLocationStream struct {
+	proto.WaCoreGateway_StreamLiveLocationServer
+	authorized bool
+}
+
+func (s *authorizedLiveLocationStream) Recv() (*proto.LiveLocationUpdate, error) {
+	update, err := s.WaCoreGateway_StreamLiveLocationServer.Recv()
+	if err != nil || s.authorized {
+		return update, err
+	}
+	if err := auth.Authorize(s.Context(), update.SenderJid); err != nil {
+		return nil, err
+	}
+	s.authorized = true
+	return update, nil
+}
+
+// authorizedUploadMediaStream authorizes the sender of the upload metadata
+type authorizedUploadMediaStream struct {
+	proto.WaCoreGateway_UploadMediaServer
+}
+
+func (s *authorizedUploadMediaStream) Recv() (*proto.UploadMediaRequest, error) {
+	req, err := s.WaCoreGateway_UploadMediaServer.Recv()
+	if err != nil {
+		return req, err
+	}
+	if meta := req.GetMetadata(); meta != nil {
+		if err := auth.Authorize(s.Context(), meta.SenderJid); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,439 @@
+package handler
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"wacoregateway/internal/auth"
+	"wacoregateway/internal/metrics"
+	"wacoregateway/model/constant"
+	proto "wacoregateway/model/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxJSONBodySize bounds the JSON request bodies, media is uploaded through /v1/media
+const maxJSONBodySize = 8 << 20
+
+// Request and response bodies of the gateway
+const (
+	bodyNone   = ""
+	bodyJSON   = "application/json"
+	bodyNDJSON = "application/x-ndjson"
+	bodyBinary = "application/octet-stream"
+	bodySSE    = "text/event-stream"
+)
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// route maps an HTTP endpoint to an RPC of WaCoreGateway, the same table serves the requests and the OpenAPI document
+type route struct {
+	method   string
+	path     string
+	rpc      string
+	request  string // body of the request, fields of the request message not in the path or body are query parameters
+	response string
+	handle   func(w http.ResponseWriter, r *http.Request) error
+}
+
+// Gateway serves the WaCoreGateway RPCs as a REST/JSON API. Requests go through the gRPC handlers,
+// so validation and authorization are the same as over gRPC.
+type Gateway struct {
+	api           proto.WaCoreGatewayServer
+	authenticator *auth.Authenticator
+	routes        []route
+	openAPI       []byte
+
+	mu     sync.Mutex
+	server *http.Server
+	closed bool
+}
+
+func newGateway(api proto.WaCoreGatewayServer, authenticator *auth.Authenticator) (*Gateway, error) {
+	g := &Gateway{api: api, authenticator: authenticator}
+	g.routes = []route{
+		{http.MethodGet, "/v1/devices", "GetAllDevice", bodyNone, bodyJSON, g.getAllDevice},
+		{http.MethodGet, "/v1/devices/connect", "StreamConnectDevice", bodyNone, bodySSE, g.streamConnectDevice},
+		{http.MethodGet, "/v1/devices/{sender_jid}/contacts", "GetClientContact", bodyNone, bodyJSON, g.getClientContact},
+		{http.MethodGet, "/v1/devices/{sender_jid}/groups", "GetClientGroup", bodyNone, bodyJSON, g.getClientGroup},
+		{http.MethodPost, "/v1/devices/{sender_jid}/logout", "LogoutDevice", bodyNone, bodyJSON, g.logoutDevice},
+		{http.MethodDelete, "/v1/devices/{sender_jid}", "DeleteDevice", bodyNone, bodyJSON, g.deleteDevice},
+		{http.MethodPost, "/v1/messages", "SendMessage", bodyJSON, bodyJSON, g.sendMessage},
+		{http.MethodPost, "/v1/messages/reaction", "SendReaction", bodyJSON, bodyJSON, g.sendReaction},
+		{http.MethodPost, "/v1/messages/revoke", "RevokeMessage", bodyJSON, bodyJSON, g.revokeMessage},
+		{http.MethodPost, "/v1/messages/edit", "EditMessage", bodyJSON, bodyJSON, g.editMessage},
+		{http.MethodPost, "/v1/live-locations", "StreamLiveLocation", bodyNDJSON, bodyJSON, g.streamLiveLocation},
+		{http.MethodPost, "/v1/media", "UploadMedia", bodyBinary, bodyJSON, g.uploadMedia},
+	}
+
+	doc, err := openAPIDocument(g.routes)
+	if err != nil {
+		return nil, err
+	}
+	g.openAPI = doc
+	return g, nil
+}
+
+// Handler returns the HTTP handler of the gateway, /openapi.json is served without credentials
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, rt := range g.routes {
+		mux.Handle(rt.method+" "+rt.path, g.serve(rt))
+	}
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(g.openAPI)
+	})
+	return mux
+}
+
+// ListenAndServe serves the gateway on addr until Shutdown is called
+func (g *Gateway) ListenAndServe(addr string, tlsConfig *tls.Config) error {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return nil
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           g.Handler(),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	g.server = server
+	g.mu.Unlock()
+
+	var err error
+	if tlsConfig != nil {
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting requests and waits until the requests in flight are done,
+// the remaining connections are closed forcibly once ctx is done
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	g.mu.Lock()
+	g.closed = true
+	server := g.server
+	g.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	if err := server.Shutdown(ctx); err != nil {
+		_ = server.Close()
+		return err
+	}
+	return nil
+}
+
+// serve gives the handler of rt the request context a gRPC call would have: the request ID, the credentials
+// as incoming metadata, the client certificate as peer and the authenticated principal.
+// Calls are counted under the gRPC method they mirror.
+func (g *Gateway) serve(rt route) http.Handler {
+	fullMethod := "/" + proto.WaCoreGateway_ServiceDesc.ServiceName + "/" + rt.rpc
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		var err error
+		defer func() { metrics.ObserveRPC(fullMethod, start, err) }()
+
+		md := metadata.MD{}
+		for _, header := range []string{constant.ReqIDLog, auth.APIKeyHeader, auth.AuthorizationHeader} {
+			if values := r.Header.Values(header); len(values) > 0 {
+				md.Set(header, values...)
+			}
+		}
+		ctx := metadata.NewIncomingContext(r.Context(), md)
+		if r.TLS != nil {
+			ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: *r.TLS}})
+		}
+
+		ctx, requestID := withRequestID(ctx)
+		w.Header().Set(constant.ReqIDLog, requestID)
+
+		if g.authenticator != nil {
+			var principal *auth.Principal
+			principal, err = g.authenticator.Authenticate(ctx)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			ctx = auth.WithPrincipal(ctx, principal)
+		}
+
+		if err = rt.handle(w, r.WithContext(ctx)); err != nil {
+			writeError(w, err)
+		}
+	})
+}
+
+func (g *Gateway) getAllDevice(w http.ResponseWriter, r *http.Request) error {
+	resp, err := g.api.GetAllDevice(r.Context(), &emptypb.Empty{})
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) getClientContact(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.ClientdataRequest{}
+	if err := decodeParams(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.GetClientContact(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) getClientGroup(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.ClientdataRequest{}
+	if err := decodeParams(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.GetClientGroup(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) logoutDevice(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.ClientdataRequest{}
+	if err := decodeParams(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.LogoutDevice(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) deleteDevice(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.ClientdataRequest{}
+	if err := decodeParams(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.DeleteDevice(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) sendMessage(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.MessagePayload{}
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.SendMessage(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) sendReaction(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.ReactionRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.SendReaction(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) revokeMessage(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.RevokeMessageRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.RevokeMessage(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) editMessage(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.EditMessageRequest{}
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+	resp, err := g.api.EditMessage(r.Context(), req)
+	return writeResponse(w, resp, err)
+}
+
+func (g *Gateway) streamConnectDevice(w http.ResponseWriter, r *http.Request) error {
+	req := &proto.ConnectDeviceRequest{}
+	if err := decodeParams(r, req); err != nil {
+		return err
+	}
+	stream, err := newEventStream(w, r)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	if err := g.api.StreamConnectDevice(req, stream); err != nil {
+		if !stream.started() {
+			return err
+		}
+		stream.sendError(err)
+	}
+	return nil
+}
+
+func (g *Gateway) streamLiveLocation(w http.ResponseWriter, r *http.Request) error {
+	stream := &liveLocationStream{httpStream: newHTTPStream(w, r), decoder: json.NewDecoder(r.Body)}
+	defer stream.close()
+	return g.api.StreamLiveLocation(stream)
+}
+
+func (g *Gateway) uploadMedia(w http.ResponseWriter, r *http.Request) error {
+	meta := &proto.UploadMediaMetadata{}
+	if err := decodeParams(r, meta); err != nil {
+		return err
+	}
+	stream := &uploadMediaStream{httpStream: newHTTPStream(w, r), meta: meta}
+	defer stream.close()
+	return g.api.UploadMedia(stream)
+}
+
+// decodeBody decodes the JSON body of r into msg
+func decodeBody(r *http.Request, msg protobuf.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxJSONBodySize))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+	}
+	if err := unmarshalOptions.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// decodeParams sets the scalar fields of msg from the path and query parameters of r
+func decodeParams(r *http.Request, msg protobuf.Message) error {
+	query := r.URL.Query()
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		name := string(field.Name())
+
+		value := r.PathValue(name)
+		if value == "" {
+			if !query.Has(name) {
+				continue
+			}
+			value = query.Get(name)
+		}
+		if field.IsList() || field.IsMap() {
+			return status.Errorf(codes.InvalidArgument, "parameter %s cannot be set in the url", name)
+		}
+
+		v, err := scalarValue(field, value)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid parameter %s: %v", name, err)
+		}
+		m.Set(field, v)
+	}
+	return nil
+}
+
+func scalarValue(field protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.DoubleKind:
+		n, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(n), err
+	case protoreflect.FloatKind:
+		n, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(n)), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported type %s", field.Kind())
+}
+
+func writeResponse(w http.ResponseWriter, resp protobuf.Message, err error) error {
+	if err != nil {
+		return err
+	}
+	body, err := marshalOptions.Marshal(resp)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+	w.Header().Set("Content-Type", bodyJSON)
+	_, _ = w.Write(body)
+	return nil
+}
+
+// errorBody is the JSON body of a failed request
+type errorBody struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func newErrorBody(err error) errorBody {
+	st := status.Convert(err)
+	return errorBody{Code: int(st.Code()), Status: st.Code().String(), Message: st.Message()}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	body := newErrorBody(err)
+	w.Header().Set("Content-Type", bodyJSON)
+	w.WriteHeader(httpStatus(codes.Code(body.Code)))
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// httpStatus maps a gRPC status code to the HTTP status of the gateway response
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	proto "wacoregateway/model/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// uploadChunkSize is the size of the chunks the raw upload body is handed to the service in
+const uploadChunkSize = 64 << 10
+
+// errStreamClosed is returned when the service writes to a stream whose request already ended,
+// like the pair success event of a device whose connect request has returned
+var errStreamClosed = status.Errorf(codes.Canceled, "http stream closed")
+
+// httpStream implements grpc.ServerStream on top of an HTTP request, metadata is not supported
+type httpStream struct {
+	w      http.ResponseWriter
+	r      *http.Request
+	mu     sync.Mutex
+	closed bool
+}
+
+func newHTTPStream(w http.ResponseWriter, r *http.Request) httpStream {
+	return httpStream{w: w, r: r}
+}
+
+func (s *httpStream) Context() context.Context     { return s.r.Context() }
+func (s *httpStream) SetHeader(metadata.MD) error  { return nil }
+func (s *httpStream) SendHeader(metadata.MD) error { return nil }
+func (s *httpStream) SetTrailer(metadata.MD)       {}
+
+func (s *httpStream) SendMsg(m any) error {
+	return status.Errorf(codes.Unimplemented, "SendMsg is not supported")
+}
+
+func (s *httpStream) RecvMsg(m any) error {
+	return status.Errorf(codes.Unimplemented, "RecvMsg is not supported")
+}
+
+// close makes later writes fail, the response writer cannot be used once the handler returned
+func (s *httpStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *httpStream) write(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errStreamClosed
+	}
+	return fn()
+}
+
+// sendAndClose writes the response of a client streaming call
+func (s *httpStream) sendAndClose(resp protobuf.Message) error {
+	return s.write(func() error {
+		return writeResponse(s.w, resp, nil)
+	})
+}
+
+// eventStream delivers the events of StreamConnectDevice as Server-Sent Events
+type eventStream struct {
+	httpStream
+	flusher http.Flusher
+	sent    bool
+}
+
+func newEventStream(w http.ResponseWriter, r *http.Request) (*eventStream, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "streaming is not supported by the connection")
+	}
+	return &eventStream{httpStream: newHTTPStream(w, r), flusher: flusher}, nil
+}
+
+func (s *eventStream) Send(event *proto.EventResponse) error {
+	data, err := marshalOptions.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.writeEvent(event.Type, data)
+}
+
+func (s *eventStream) sendError(err error) {
+	data, _ := json.Marshal(newErrorBody(err))
+	_ = s.writeEvent("error", data)
+}
+
+func (s *eventStream) writeEvent(name string, data []byte) error {
+	return s.write(func() error {
+		if !s.sent {
+			s.w.Header().Set("Content-Type", bodySSE)
+			s.w.Header().Set("Cache-Control", "no-cache")
+			s.w.WriteHeader(http.StatusOK)
+			s.sent = true
+		}
+		if _, err := s.w.Write([]byte("event: " + name + "\ndata: " + string(data) + "\n\n")); err != nil {
+			return err
+		}
+		s.flusher.Flush()
+		return nil
+	})
+}
+
+// started reports whether an event was sent, after that errors can only be sent as error events
+func (s *eventStream) started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent
+}
+
+// liveLocationStream reads the updates of StreamLiveLocation from a newline delimited JSON body
+type liveLocationStream struct {
+	httpStream
+	decoder *json.Decoder
+}
+
+func (s *liveLocationStream) Recv() (*proto.LiveLocationUpdate, error) {
+	var raw json.RawMessage
+	if err := s.decoder.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "invalid live location update: %v", err)
+	}
+	update := &proto.LiveLocationUpdate{}
+	if err := unmarshalOptions.Unmarshal(raw, update); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid live location update: %v", err)
+	}
+	return update, nil
+}
+
+func (s *liveLocationStream) SendAndClose(resp *proto.LiveLocationResponse) error {
+	return s.sendAndClose(resp)
+}
+
+// uploadMediaStream hands the metadata from the query and the raw body of an upload to UploadMedia
+type uploadMediaStream struct {
+	httpStream
+	meta *proto.UploadMediaMetadata
+}
+
+func (s *uploadMediaStream) Recv() (*proto.UploadMediaRequest, error) {
+	if s.meta != nil {
+		meta := s.meta
+		s.meta = nil
+		return &proto.UploadMediaRequest{Payload: &proto.UploadMediaRequest_Metadata{Metadata: meta}}, nil
+	}
+
+	chunk := make([]byte, uploadChunkSize)
+	n, err := io.ReadFull(s.r.Body, chunk)
+	if n > 0 {
+		return &proto.UploadMediaRequest{Payload: &proto.UploadMediaRequest_Chunk{Chunk: chunk[:n]}}, nil
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, io.EOF
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "failed to read upload body: %v", err)
+}
+
+func (s *uploadMediaStream) SendAndClose(resp *proto.UploadMediaResponse) error {
+	return s.sendAndClose(resp)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 
+	"wacoregateway/internal/auth"
 	proto "wacoregateway/model/pb"
 
 	"google.golang.org/grpc/codes"
@@ -16,6 +17,9 @@ func (s *server) GetClientContact(ctx context.Context, req *proto.ClientdataRequ
 	if senderJID == "" {
 		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
 	}
+	if err := auth.Authorize(ctx, senderJID); err != nil {
+		return nil, err
+	}
 	result, err := s.service.ProcessGetContact(ctx, senderJID)
 	if err != nil {
 		return nil, err
@@ -30,6 +34,9 @@ func (s *server) GetClientGroup(ctx context.Context, req *proto.ClientdataReques
 	if senderJID == "" {
 		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
 	}
+	if err := auth.Authorize(ctx, senderJID); err != nil {
+		return nil, err
+	}
 	result, err := s.service.ProcessGetGroup(ctx, senderJID)
 	if err != nil {
 		return nil, err
@@ -43,13 +50,55 @@ func (s *server) GetAllDevice(ctx context.Context, empty *emptypb.Empty) (*proto
 	if err != nil {
 		return nil, err
 	}
+
+	devices := result.Devices[:0]
+	for _, device := range result.Devices {
+		if auth.Allowed(ctx, device.Jid) {
+			devices = append(devices, device)
+		}
+	}
+	result.Devices = devices
+
 	return result, nil
 }
 
+func (s *server) LogoutDevice(ctx context.Context, req *proto.ClientdataRequest) (*emptypb.Empty, error) {
+	if req.SenderJid == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
+	}
+	if err := auth.Authorize(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.ProcessLogoutDevice(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) DeleteDevice(ctx context.Context, req *proto.ClientdataRequest) (*emptypb.Empty, error) {
+	if req.SenderJid == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
+	}
+	if err := auth.Authorize(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.ProcessDeleteDevice(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 func (s *server) SendMessage(ctx context.Context, req *proto.MessagePayload) (*proto.MessageResponse, error) {
 	if req.SenderJid == "" {
 		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
 	}
+	if err := auth.Authorize(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
 	if req.To == "" {
 		return nil, status.Errorf(codes.PermissionDenied, "to param cannot be empty")
 	}
@@ -62,9 +111,106 @@ func (s *server) SendMessage(ctx context.Context, req *proto.MessagePayload) (*p
 	return result, nil
 }
 
+func (s *server) SendReaction(ctx context.Context, req *proto.ReactionRequest) (*proto.MessageResponse, error) {
+	if req.SenderJid == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
+	}
+	if err := auth.Authorize(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
+	if req.ChatJid == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "chatJID param cannot be empty")
+	}
+	if req.MessageId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "messageID param cannot be empty")
+	}
+
+	result, err := s.service.ProcessSendReaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *server) RevokeMessage(ctx context.Context, req *proto.RevokeMessageRequest) (*proto.MessageResponse, error) {
+	if req.SenderJid == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
+	}
+	if err := auth.Authorize(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
+	if req.ChatJid == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "chatJID param cannot be empty")
+	}
+	if req.MessageId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "messageID param cannot be empty")
+	}
+
+	result, err := s.service.ProcessRevokeMessage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *server) EditMessage(ctx context.Context, req *proto.EditMessageRequest) (*proto.MessageResponse, error) {
+	if req.SenderJid == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
+	}
+	if err := auth.Authorize(ctx, req.SenderJid); err != nil {
+		return nil, err
+	}
+	if req.ChatJid == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "chatJID param cannot be empty")
+	}
+	if req.MessageId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "messageID param cannot be empty")
+	}
+	if req.Text == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "text param cannot be empty")
+	}
+
+	result, err := s.service.ProcessEditMessage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (s *server) StreamConnectDevice(req *proto.ConnectDeviceRequest, stream proto.WaCoreGateway_StreamConnectDeviceServer) error {
+	if err := auth.Authorize(stream.Context(), req.Name); err != nil {
+		return err
+	}
+	if req.PhoneNumber != "" {
+		if err := auth.Authorize(stream.Context(), req.PhoneNumber); err != nil {
+			return err
+		}
+	}
+
+	err := s.service.ConnectDevice(stream.Context(), s.container, req, stream)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *server) StreamLiveLocation(stream proto.WaCoreGateway_StreamLiveLocationServer) error {
+
+	err := s.service.ProcessStreamLiveLocation(stream.Context(), &authorizedLiveLocationStream{WaCoreGateway_StreamLiveLocationServer: stream})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *server) UploadMedia(stream proto.WaCoreGateway_UploadMediaServer) error {
 
-	err := s.service.ConnectDevice(context.Background(), s.container, req, stream)
+	err := s.service.ProcessUploadMedia(stream.Context(), &authorizedUploadMediaStream{WaCoreGateway_UploadMediaServer: stream})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	proto "wacoregateway/model/pb"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPIDocument generates the OpenAPI 3 document of the gateway from the descriptors of wacore.proto,
+// so the request and response schemas follow the proto without a separate spec to keep in sync
+func openAPIDocument(routes []route) ([]byte, error) {
+	service := proto.File_model_proto_wacore_proto.Services().ByName("WaCoreGateway")
+	if service == nil {
+		return nil, fmt.Errorf("service WaCoreGateway not found in proto descriptor")
+	}
+
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "format": "int32", "description": "gRPC status code"},
+				"status":  map[string]any{"type": "string", "description": "gRPC status name"},
+				"message": map[string]any{"type": "string"},
+			},
+		},
+	}
+	paths := map[string]map[string]any{}
+
+	for _, rt := range routes {
+		method := service.Methods().ByName(protoreflect.Name(rt.rpc))
+		if method == nil {
+			return nil, fmt.Errorf("rpc %s not found in proto descriptor", rt.rpc)
+		}
+		input := method.Input()
+		if rt.rpc == "UploadMedia" {
+			// The metadata of an upload is sent in the query, the chunks are the raw body
+			input = (&proto.UploadMediaMetadata{}).ProtoReflect().Descriptor()
+		}
+
+		operation := map[string]any{
+			"operationId": rt.rpc,
+			"tags":        []string{strings.Split(strings.TrimPrefix(rt.path, "/v1/"), "/")[0]},
+			"parameters":  openAPIParameters(input, rt),
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     map[string]any{rt.response: map[string]any{"schema": schemaRef(method.Output(), schemas)}},
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content":     map[string]any{bodyJSON: map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}}},
+				},
+			},
+		}
+		switch rt.request {
+		case bodyJSON, bodyNDJSON:
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{rt.request: map[string]any{"schema": schemaRef(input, schemas)}},
+			}
+		case bodyBinary:
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{rt.request: map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}}},
+			}
+		}
+		if rt.response == bodySSE {
+			operation["description"] = "Events are sent as Server-Sent Events named after their type, a failure after the first event is sent as an error event."
+		}
+
+		if paths[rt.path] == nil {
+			paths[rt.path] = map[string]any{}
+		}
+		paths[rt.path][strings.ToLower(rt.method)] = operation
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "WaCoreGateway",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-Api-Key"},
+				"bearer": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []map[string][]string{{"apiKey": {}}, {"bearer": {}}},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// openAPIParameters returns the path and query parameters of rt, a request with a body only has path parameters
+func openAPIParameters(msg protoreflect.MessageDescriptor, rt route) []map[string]any {
+	parameters := []map[string]any{}
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		name := string(field.Name())
+		in := "query"
+		if strings.Contains(rt.path, "{"+name+"}") {
+			in = "path"
+		} else if rt.request == bodyJSON || rt.request == bodyNDJSON {
+			continue
+		}
+		if field.IsList() || field.IsMap() || field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.BytesKind {
+			continue
+		}
+		parameters = append(parameters, map[string]any{
+			"name":     name,
+			"in":       in,
+			"required": in == "path",
+			"schema":   fieldSchema(field, nil),
+		})
+	}
+	return parameters
+}
+
+// schemaRef adds the schema of msg and the messages it refers to, and returns a reference to it
+func schemaRef(msg protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	name := strings.TrimPrefix(string(msg.FullName()), string(msg.ParentFile().Package())+".")
+	if msg.FullName() == "google.protobuf.Empty" {
+		name = "Empty"
+	}
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+	if _, exists := schemas[name]; exists {
+		return ref
+	}
+
+	properties := map[string]any{}
+	schemas[name] = map[string]any{"type": "object", "properties": properties}
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		properties[string(field.Name())] = fieldSchema(field, schemas)
+	}
+	return ref
+}
+
+// fieldSchema returns the schema of field as encoded by protojson
+func fieldSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	if field.IsMap() {
+		return map[string]any{"type": "object", "additionalProperties": fieldSchema(field.MapValue(), schemas)}
+	}
+
+	var schema map[string]any
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		schema = map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64 bit integers as strings
+		schema = map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		schema = map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		schema = map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		schema = map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := []string{}
+		for i := 0; i < field.Enum().Values().Len(); i++ {
+			values = append(values, string(field.Enum().Values().Get(i).Name()))
+		}
+		schema = map[string]any{"type": "string", "enum": values}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		schema = schemaRef(field.Message(), schemas)
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+
+	if field.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+
+	"wacoregateway/internal/provider/grpcx"
+	"wacoregateway/model/constant"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// maxRequestIDLength bounds caller supplied request IDs, longer ones are replaced
+const maxRequestIDLength = 128
+
+// UnaryRequestIDInterceptor puts the x-request-id of the call, or a generated one, in the context
+// and echoes it back in the response headers
+func UnaryRequestIDInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, requestID := withRequestID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(constant.ReqIDLog, requestID))
+	return handler(ctx, req)
+}
+
+// StreamRequestIDInterceptor is UnaryRequestIDInterceptor for streaming calls
+func StreamRequestIDInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, requestID := withRequestID(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(constant.ReqIDLog, requestID))
+	return handler(srv, grpcx.WithContext(ss, ctx))
+}
+
+func withRequestID(ctx context.Context) (context.Context, string) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(constant.ReqIDLog); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = uuid.New().String()
+	}
+
+	ctx = context.WithValue(ctx, constant.CtxReqIDKey, requestID)
+	ctx = context.WithValue(ctx, constant.RequestIDKey{}, requestID)
+	return ctx, requestID
+}
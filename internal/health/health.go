@@ -0,0 +1,198 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"wacoregateway/internal/cache"
+	"wacoregateway/internal/provider/amqpx"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// CheckResult is the outcome of one dependency check
+type CheckResult struct {
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report is the readiness of the gateway and its dependencies
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// amqpConnection is implemented by amqpx.Connection
+type amqpConnection interface {
+	IsClosed() bool
+}
+
+// Checker checks the database, the AMQP connection and the WhatsApp devices
+// and reports the result over grpc.health.v1 and HTTP
+type Checker struct {
+	db       *sql.DB
+	amqp     amqpConnection
+	clients  *cache.ClientRegistry
+	services []string
+	grpc     *health.Server
+}
+
+// NewChecker creates a Checker, db and amqp may be nil when the connection could not be established.
+// services are the gRPC service names whose serving status follows the readiness.
+func NewChecker(db *sql.DB, amqp amqpx.ChannelReader, clients *cache.ClientRegistry, services ...string) *Checker {
+	conn, _ := amqp.(amqpConnection)
+	return &Checker{
+		db:       db,
+		amqp:     conn,
+		clients:  clients,
+		services: append([]string{""}, services...),
+		grpc:     health.NewServer(),
+	}
+}
+
+// GRPCServer returns the grpc.health.v1 implementation to register on the gRPC server
+func (c *Checker) GRPCServer() healthpb.HealthServer {
+	return c.grpc
+}
+
+// Check runs every dependency check
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		Status: StatusUp,
+		Checks: map[string]CheckResult{
+			"database": c.checkDatabase(ctx),
+			"amqp":     c.checkAMQP(),
+			"whatsapp": c.checkDevices(),
+		},
+	}
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) CheckResult {
+	if c.db == nil {
+		return CheckResult{Status: StatusDown, Detail: "not connected"}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := c.db.PingContext(ctx); err != nil {
+		return CheckResult{Status: StatusDown, Detail: err.Error()}
+	}
+	return CheckResult{Status: StatusUp}
+}
+
+func (c *Checker) checkAMQP() CheckResult {
+	if c.amqp == nil || c.amqp.IsClosed() {
+		return CheckResult{Status: StatusDown, Detail: "connection closed"}
+	}
+	return CheckResult{Status: StatusUp}
+}
+
+// checkDevices is only down when there are paired devices and none of them is connected,
+// a single disconnected device should not take the whole gateway out of service
+func (c *Checker) checkDevices() CheckResult {
+	connected, expected := 0, 0
+	for _, client := range c.clients.Clients() {
+		if client.Store.ID == nil {
+			continue
+		}
+		expected++
+		if client.IsConnected() && client.IsLoggedIn() {
+			connected++
+		}
+	}
+
+	result := CheckResult{Status: StatusUp, Detail: fmt.Sprintf("%d/%d devices connected", connected, expected)}
+	if expected > 0 && connected == 0 {
+		result.Status = StatusDown
+	}
+	return result
+}
+
+// Run refreshes the gRPC serving status every interval until ctx is done
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.updateServingStatus(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) updateServingStatus(ctx context.Context) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if c.Check(ctx).Status != StatusUp {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range c.services {
+		c.grpc.SetServingStatus(service, status)
+	}
+}
+
+// Shutdown reports every service as not serving, so no new requests are routed to the gateway
+func (c *Checker) Shutdown() {
+	c.grpc.Shutdown()
+}
+
+// Handler serves /healthz for liveness and /readyz for readiness
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusUp})
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := c.Check(r.Context())
+		code := http.StatusOK
+		if report.Status != StatusUp {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, report)
+	})
+	return mux
+}
+
+// ListenAndServe serves the HTTP probes on addr until ctx is done
+func (c *Checker) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           c.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
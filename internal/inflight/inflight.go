@@ -0,0 +1,59 @@
+package inflight
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracker counts work in flight and refuses new work once it is draining.
+// Unlike sync.WaitGroup, Start may be called concurrently with Drain.
+type Tracker struct {
+	mu       sync.Mutex
+	count    int
+	draining bool
+	idle     chan struct{}
+}
+
+// Start registers new work, it returns false once the tracker is draining
+func (t *Tracker) Start() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.draining {
+		return false
+	}
+	t.count++
+	return true
+}
+
+// Done marks work registered by Start as finished
+func (t *Tracker) Done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.count--
+	if t.count == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// Drain refuses new work and waits until the work in flight is done or ctx is done
+func (t *Tracker) Drain(ctx context.Context) error {
+	t.mu.Lock()
+	t.draining = true
+	if t.count == 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+	idle := t.idle
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
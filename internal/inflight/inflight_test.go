@@ -0,0 +1,65 @@
+package inflight
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDrainWaitsForWorkInFlight(t *testing.T) {
+	var tracker Tracker
+	if !tracker.Start() {
+		t.Fatal("Start before Drain = false, want true")
+	}
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- tracker.Drain(context.Background())
+	}()
+
+	select {
+	case <-drained:
+		t.Fatal("Drain returned with work in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	tracker.Done()
+	if err := <-drained; err != nil {
+		t.Fatalf("Drain = %v, want nil", err)
+	}
+	if tracker.Start() {
+		t.Error("Start after Drain = true, want false")
+	}
+}
+
+func TestDrainTimesOut(t *testing.T) {
+	var tracker Tracker
+	tracker.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := tracker.Drain(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Drain = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestStartConcurrentWithDrain(t *testing.T) {
+	var tracker Tracker
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if tracker.Start() {
+				time.Sleep(time.Millisecond)
+				tracker.Done()
+			}
+		}()
+	}
+
+	if err := tracker.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain = %v, want nil", err)
+	}
+	wg.Wait()
+}
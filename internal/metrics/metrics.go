@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "wacore"
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+var (
+	rpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_requests_total",
+		Help:      "gRPC calls, including those served by the HTTP gateway, by method and status code.",
+	}, []string{"method", "code"})
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "gRPC call duration by method, including the HTTP gateway.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	messagesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_sent_total",
+		Help:      "Outbound messages by type and outcome.",
+	}, []string{"type", "outcome"})
+	messagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_received_total",
+		Help:      "Inbound messages by type.",
+	}, []string{"type"})
+	receipts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "receipts_total",
+		Help:      "Receipts by type.",
+	}, []string{"type"})
+
+	amqpPublish = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "amqp_publish_total",
+		Help:      "AMQP publications by queue and outcome.",
+	}, []string{"queue", "outcome"})
+	amqpPublishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "amqp_publish_duration_seconds",
+		Help:      "AMQP publication latency by queue.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"queue"})
+
+	reconnectAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconnect_attempts_total",
+		Help:      "Device reconnect attempts by outcome.",
+	}, []string{"outcome"})
+
+	mediaUploadBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "media_upload_bytes_total",
+		Help:      "Bytes of media uploaded to WhatsApp by type.",
+	}, []string{"type"})
+	mediaUploadDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "media_upload_duration_seconds",
+		Help:      "Duration of media uploads to WhatsApp by type and outcome.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"type", "outcome"})
+)
+
+func outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
+
+// ObserveMessageSent counts an outbound message
+func ObserveMessageSent(messageType string, err error) {
+	messagesSent.WithLabelValues(messageType, outcome(err)).Inc()
+}
+
+// ObserveMessageReceived counts an inbound message
+func ObserveMessageReceived(messageType string) {
+	messagesReceived.WithLabelValues(messageType).Inc()
+}
+
+// ObserveReceipt counts a receipt
+func ObserveReceipt(receiptType string) {
+	receipts.WithLabelValues(receiptType).Inc()
+}
+
+// ObservePublish records an AMQP publication started at start
+func ObservePublish(queue string, start time.Time, err error) {
+	amqpPublish.WithLabelValues(queue, outcome(err)).Inc()
+	amqpPublishDuration.WithLabelValues(queue).Observe(time.Since(start).Seconds())
+}
+
+// ObserveReconnectAttempt counts a device reconnect attempt
+func ObserveReconnectAttempt(err error) {
+	reconnectAttempts.WithLabelValues(outcome(err)).Inc()
+}
+
+// ObserveMediaUpload records a media upload of size bytes started at start
+func ObserveMediaUpload(messageType string, size int, start time.Time, err error) {
+	if err == nil {
+		mediaUploadBytes.WithLabelValues(messageType).Add(float64(size))
+	}
+	mediaUploadDuration.WithLabelValues(messageType, outcome(err)).Observe(time.Since(start).Seconds())
+}
+
+// RegisterConnectedDevices exposes the number of connected devices, counted on every scrape
+func RegisterConnectedDevices(count func() int) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "devices_connected",
+		Help:      "Devices connected and logged in to WhatsApp.",
+	}, func() float64 {
+		return float64(count())
+	})
+}
+
+// UnaryServerInterceptor counts unary RPCs by method and status code
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	ObserveRPC(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServerInterceptor counts streaming RPCs by method and status code
+func StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	ObserveRPC(info.FullMethod, start, err)
+	return err
+}
+
+// ObserveRPC records a call of the full gRPC method started at start, also when it is served by the HTTP gateway
+func ObserveRPC(method string, start time.Time, err error) {
+	rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// ListenAndServe serves /metrics on addr until ctx is done
+func ListenAndServe(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"fmt"
+
+	"wacoregateway/internal/provider/blobstore"
+	"wacoregateway/util"
+)
+
+func NewBlobStore() (blobstore.Store, error) {
+	cfg := util.Configuration.Media.Store
+
+	switch cfg.Driver {
+	case "", "local":
+		return blobstore.NewLocalStore(cfg.Local.Dir)
+	case "s3":
+		return blobstore.NewS3Store(blobstore.S3Options{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			Prefix:    cfg.S3.Prefix,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			UseSSL:    cfg.S3.UseSSL,
+		})
+	}
+
+	return nil, fmt.Errorf("%w: %s", blobstore.ErrUnknownDriver, cfg.Driver)
+}
@@ -0,0 +1,37 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+)
+
+var (
+	ErrUnknownDriver = errors.New("unknown blob store driver")
+	ErrInvalidKey    = errors.New("invalid blob key")
+)
+
+// Object describes a stored blob
+type Object struct {
+	Ref  string // stable reference to the blob, e.g. local:<key> or s3://<bucket>/<key>
+	Path string // absolute path on the local filesystem, empty for remote stores
+}
+
+// Store persists blobs under a caller chosen key
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) (*Object, error)
+}
+
+// checkKey rejects keys that are empty, absolute or would leave the base directory or prefix of a store
+func checkKey(key string) error {
+	if key == "" || path.IsAbs(key) || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, element := range strings.Split(key, "/") {
+		if element == "" || element == "." || element == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
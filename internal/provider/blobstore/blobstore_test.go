@@ -0,0 +1,76 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var keyTests = []struct {
+	key   string
+	valid bool
+}{
+	{"6281111/ABCDEF.jpg", true},
+	{"6281111/..jpg", true},
+	{"a/b/c", true},
+	{"", false},
+	{"../x", false},
+	{"a/../../x", false},
+	{"a/..", false},
+	{"..", false},
+	{"/abs", false},
+	{"/etc/passwd", false},
+	{"a//b", false},
+	{"a/./b", false},
+	{"a/", false},
+	{`a\b`, false},
+	{`..\x`, false},
+}
+
+func TestCheckKey(t *testing.T) {
+	for _, tt := range keyTests {
+		err := checkKey(tt.key)
+		if tt.valid && err != nil {
+			t.Errorf("checkKey(%q) = %v, want nil", tt.key, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("checkKey(%q) = %v, want %v", tt.key, err, ErrInvalidKey)
+		}
+	}
+}
+
+func TestLocalStorePutStaysInDir(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(filepath.Join(root, "media"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range keyTests {
+		object, err := store.Put(context.Background(), tt.key, []byte("data"), "image/jpeg")
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Put(%q) = %v, want %v", tt.key, err, ErrInvalidKey)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Put(%q) = %v", tt.key, err)
+			continue
+		}
+		if want := filepath.Join(store.dir, filepath.FromSlash(tt.key)); object.Path != want {
+			t.Errorf("Put(%q) path = %s, want %s", tt.key, object.Path, want)
+		}
+	}
+
+	// Nothing may have been written next to the store directory
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("files outside the store directory: %v", entries)
+	}
+}
@@ -0,0 +1,56 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore stores blobs on the local filesystem under a base directory
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(abs, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return &LocalStore{dir: abs}, nil
+}
+
+// Put writes the blob to a temporary file first so readers never see a partial file
+func (l *LocalStore) Put(ctx context.Context, key string, data []byte, contentType string) (*Object, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(l.dir, filepath.FromSlash(key))
+	if rel, err := filepath.Rel(l.dir, path); err != nil || !filepath.IsLocal(rel) {
+		return nil, ErrInvalidKey
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+
+	return &Object{Ref: "local:" + key, Path: path}, nil
+}
@@ -0,0 +1,22 @@
+package grpcx
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ContextStream is a server stream whose context is replaced, interceptors use it to pass values to the handler
+type ContextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// WithContext returns ss with ctx as its context
+func WithContext(ss grpc.ServerStream, ctx context.Context) *ContextStream {
+	return &ContextStream{ServerStream: ss, ctx: ctx}
+}
+
+func (s *ContextStream) Context() context.Context {
+	return s.ctx
+}
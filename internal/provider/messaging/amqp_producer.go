@@ -5,13 +5,23 @@ import (
 	"encoding/json"
 
 	"sync"
+	"time"
 
+	"wacoregateway/internal/inflight"
+	"wacoregateway/internal/metrics"
 	"wacoregateway/internal/provider/amqpx"
+	"wacoregateway/model/constant"
 
 	"github.com/pkg/errors"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("wacoregateway/internal/provider/messaging")
+
 var (
 	defaultAMQPPublishing = &amqp.Publishing{
 		DeliveryMode: amqp.Persistent,
@@ -33,17 +43,28 @@ func (o *AMQPPublisherOptions) reset() {
 	o.Publishing = nil
 }
 
+// correlated is implemented by messages carrying the request ID that caused them
+type correlated interface {
+	SetCorrelationID(id string)
+}
+
 type AMQPPublisherInterface interface {
 	Publish(ctx context.Context, queue string, message any, options ...AMQPPublisherOption) error
+	// Flush refuses new publications and waits until the publications in flight are done or ctx is done
+	Flush(ctx context.Context) error
 }
 
 type AMQPPublisherOption func(options *AMQPPublisherOptions)
 
 type AMQPPublisher struct {
-	pool *sync.Pool
-	conn amqpx.ChannelReader
+	pool     *sync.Pool
+	conn     amqpx.ChannelReader
+	inflight inflight.Tracker
 }
 
+// ErrPublisherFlushed is returned by Publish once the publisher is flushed for shutdown
+var ErrPublisherFlushed = errors.New("publisher is shutting down")
+
 func NewAMQPPublisher(conn amqpx.ChannelReader) AMQPPublisherInterface {
 
 	return &AMQPPublisher{
@@ -56,13 +77,35 @@ func NewAMQPPublisher(conn amqpx.ChannelReader) AMQPPublisherInterface {
 	}
 }
 
-func (p *AMQPPublisher) Publish(ctx context.Context, queue string, message any, options ...AMQPPublisherOption) error {
+func (p *AMQPPublisher) Publish(ctx context.Context, queue string, message any, options ...AMQPPublisherOption) (err error) {
+	if !p.inflight.Start() {
+		return ErrPublisherFlushed
+	}
+	defer p.inflight.Done()
+
+	ctx, span := tracer.Start(ctx, "publish "+queue, trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("messaging.system", "rabbitmq"), attribute.String("messaging.destination.name", queue)))
+	start := time.Now()
+	defer func() {
+		metrics.ObservePublish(queue, start, err)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	channel, err := p.conn.Channel()
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	defer channel.Close()
 
+	correlationID, _ := ctx.Value(constant.RequestIDKey{}).(string)
+	if m, ok := message.(correlated); ok && correlationID != "" {
+		m.SetCorrelationID(correlationID)
+	}
+
 	body, err := json.Marshal(message)
 	if err != nil {
 		return err
@@ -86,6 +129,10 @@ func (p *AMQPPublisher) Publish(ctx context.Context, queue string, message any,
 		return errors.WithStack(err)
 	}
 
+	// Consumers continue the trace from the headers
+	headers := amqp.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, amqpHeaderCarrier(headers))
+
 	return channel.PublishWithContext(
 		ctx,
 		opts.Exchange,
@@ -93,8 +140,34 @@ func (p *AMQPPublisher) Publish(ctx context.Context, queue string, message any,
 		opts.Mandatory,
 		opts.Immediate,
 		amqp.Publishing{
-			DeliveryMode: opts.Publishing.DeliveryMode,
-			ContentType:  opts.Publishing.ContentType,
-			Body:         body,
+			Headers:       headers,
+			CorrelationId: correlationID,
+			DeliveryMode:  opts.Publishing.DeliveryMode,
+			ContentType:   opts.Publishing.ContentType,
+			Body:          body,
 		})
 }
+
+func (p *AMQPPublisher) Flush(ctx context.Context) error {
+	return p.inflight.Drain(ctx)
+}
+
+// amqpHeaderCarrier adapts amqp.Table to propagation.TextMapCarrier
+type amqpHeaderCarrier amqp.Table
+
+func (c amqpHeaderCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c amqpHeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c amqpHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -1,17 +1,20 @@
 package provider
 
 import (
+	"database/sql"
+
 	"go.mau.fi/whatsmeow/store/sqlstore"
 	waLog "go.mau.fi/whatsmeow/util/log"
 )
 
-func SqlStoreContainer() (*sqlstore.Container, error) {
+// SqlStoreContainer returns the whatsmeow device store and the database it uses
+func SqlStoreContainer() (*sqlstore.Container, *sql.DB, error) {
 	sqlDB, err := NewPostgresConnection()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// defer sqlDB.Close()
 	dbLog := waLog.Stdout("Database", "DEBUG", true)
 	container := sqlstore.NewWithDB(sqlDB, "postgres", dbLog)
-	return container, nil
+	return container, sqlDB, nil
 }
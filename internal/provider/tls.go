@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"wacoregateway/util"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay collapses the burst of events of a certificate rotation into one reload
+const reloadDelay = 500 * time.Millisecond
+
+// CertReloader serves the TLS certificate and client CA of the gRPC and HTTP gateway listeners
+// and reloads them when the files change
+type CertReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	config       atomic.Pointer[tls.Config]
+}
+
+// NewCertReloader loads the TLS files of the server configuration
+func NewCertReloader() (*CertReloader, error) {
+	cfg := util.Configuration.Server.TLS
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("tls cert_file and key_file are required")
+	}
+
+	r := &CertReloader{
+		certFile:     cfg.CertFile,
+		keyFile:      cfg.KeyFile,
+		clientCAFile: cfg.ClientCAFile,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *CertReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificate found in client ca %s", r.clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.config.Store(config)
+	return nil
+}
+
+// TLSConfig returns the config to create the server credentials with, every handshake uses the latest loaded files
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.config.Load(), nil
+		},
+	}
+}
+
+// Watch reloads the files when they change until ctx is done. The directories are watched rather than
+// the files, so files replaced by rename, like mounted Kubernetes secrets, are picked up as well.
+// A failed reload keeps the previous certificate.
+func (r *CertReloader) Watch(ctx context.Context, logger ILogger) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	dirs := map[string]struct{}{}
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file != "" {
+			dirs[filepath.Dir(file)] = struct{}{}
+		}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) || event.Has(fsnotify.Remove) {
+				timer.Reset(reloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Errorfctx(AppLog, ctx, false, "TLS file watcher error: %v", err)
+		case <-timer.C:
+			if err := r.reload(); err != nil {
+				logger.Errorfctx(AppLog, ctx, false, "Failed to reload TLS files, keeping the previous certificate: %v", err)
+				continue
+			}
+			logger.Infofctx(AppLog, ctx, "TLS files reloaded")
+		}
+	}
+}
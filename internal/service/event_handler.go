@@ -2,7 +2,12 @@ package service
 
 import (
 	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
 
+	"wacoregateway/internal/cache"
+	"wacoregateway/internal/metrics"
 	"wacoregateway/internal/provider"
 	"wacoregateway/internal/provider/messaging"
 	"wacoregateway/model"
@@ -12,21 +17,39 @@ import (
 	proto "wacoregateway/model/pb"
 
 	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
 
-func AttachAllHandlers(senderJid string, publisher messaging.AMQPPublisherInterface, logger provider.ILogger, client *whatsmeow.Client, stream proto.WaCoreGateway_StreamConnectDeviceServer) {
-	eventBuilder := model.NewEventBuilder(senderJid)
-	ctx := context.WithValue(context.Background(), constant.CtxReqIDKey, senderJid)
-
+func AttachAllHandlers(clients *cache.ClientRegistry, reconnect *ReconnectSupervisor, senderJid string, publisher messaging.AMQPPublisherInterface, logger provider.ILogger, media *MediaDownloader, polls *cache.PollCache, client *whatsmeow.Client, stream proto.WaCoreGateway_StreamConnectDeviceServer) {
 	client.AddEventHandler(func(evt interface{}) {
-		HandleConnectionEvents(senderJid, publisher, logger, eventBuilder, stream, ctx, evt)
-		HandleMessageEvents(senderJid, publisher, logger, eventBuilder, ctx, evt)
+		// A new device is cached under its pairing name until it is paired, from then on it is keyed by the device JID
+		if v, ok := evt.(*events.PairSuccess); ok {
+			clients.Rekey(senderJid, v.ID.String(), client)
+		}
+		jid := deviceJID(senderJid, client)
+
+		eventBuilder := model.NewEventBuilder(jid)
+		ctx := context.WithValue(context.Background(), constant.CtxReqIDKey, jid)
+
+		HandleDeviceStatusEvents(clients, jid, client, evt)
+		HandleReconnectEvents(reconnect, client, evt)
+		HandleConnectionEvents(jid, publisher, logger, eventBuilder, stream, ctx, evt)
+		HandleMessageEvents(jid, publisher, logger, media, polls, client, eventBuilder, ctx, evt)
 		HandleQREvents(publisher, logger, eventBuilder, ctx, evt)
-		HandleAnyEvents(senderJid, publisher, logger, eventBuilder, ctx, evt)
+		HandleAnyEvents(jid, publisher, logger, eventBuilder, ctx, evt)
 	})
 }
 
+// deviceJID returns the device JID of a paired client, or the pairing name it was attached with
+func deviceJID(senderJid string, client *whatsmeow.Client) string {
+	if client.Store.ID != nil {
+		return client.Store.ID.String()
+	}
+	return senderJid
+}
+
 func HandleQREvents(publisher messaging.AMQPPublisherInterface, logger provider.ILogger, eventBuilder *model.EventBuilder, ctx context.Context, evt interface{}) {
 	switch v := evt.(type) {
 	case *events.QR:
@@ -42,6 +65,84 @@ func HandleQREvents(publisher messaging.AMQPPublisherInterface, logger provider.
 	}
 }
 
+// HandleDeviceStatusEvents keeps the device status reported by GetAllDevice up to date
+func HandleDeviceStatusEvents(clients *cache.ClientRegistry, senderJid string, client *whatsmeow.Client, evt interface{}) {
+	switch v := evt.(type) {
+	case *events.PairSuccess:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.State = cache.DeviceStateConnecting
+			status.Platform = v.Platform
+			status.BusinessName = v.BusinessName
+			status.LastError = ""
+		})
+	case *events.PairError:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.State = cache.DeviceStateFailed
+			status.LastError = v.Error.Error()
+		})
+	case *events.Connected:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.State = cache.DeviceStateConnected
+			status.PushName = client.Store.PushName
+			status.Platform = client.Store.Platform
+			status.BusinessName = client.Store.BusinessName
+			status.LastConnectedAt = time.Now()
+			status.LastError = ""
+			status.ReconnectAttempts = 0
+			status.NextReconnectAt = time.Time{}
+		})
+	case *events.PushNameSetting:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.PushName = v.Action.GetName()
+		})
+	case *events.Disconnected:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.State = cache.DeviceStateDisconnected
+			status.LastDisconnectedAt = time.Now()
+		})
+	case *events.LoggedOut:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.State = cache.DeviceStateLoggedOut
+			status.LastDisconnectedAt = time.Now()
+			status.LastError = v.PermanentDisconnectDescription()
+		})
+	case events.PermanentDisconnect:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.State = cache.DeviceStateDisconnected
+			status.LastDisconnectedAt = time.Now()
+			status.LastError = v.PermanentDisconnectDescription()
+		})
+	case *events.KeepAliveTimeout:
+		clients.UpdateStatus(senderJid, func(status *cache.DeviceStatus) {
+			status.LastError = fmt.Sprintf("keepalive timeout (%d errors)", v.ErrorCount)
+		})
+	}
+}
+
+// HandleReconnectEvents hands disconnected devices to the reconnect supervisor and stops it once they are logged out
+func HandleReconnectEvents(reconnect *ReconnectSupervisor, client *whatsmeow.Client, evt interface{}) {
+	switch v := evt.(type) {
+	case *events.Disconnected:
+		reconnect.Supervise(client, "disconnected")
+	case *events.StreamError:
+		reconnect.Supervise(client, "stream error "+v.Code)
+	case *events.ConnectFailure:
+		if !v.Reason.IsLoggedOut() {
+			reconnect.Supervise(client, v.PermanentDisconnectDescription())
+		}
+	case *events.KeepAliveTimeout:
+		// Auto reconnect of whatsmeow is disabled, so dead connections have to be dropped here
+		if time.Since(v.LastSuccess) > whatsmeow.KeepAliveMaxFailTime {
+			client.Disconnect()
+			reconnect.Supervise(client, "keepalive timeout")
+		}
+	case *events.Connected:
+		reconnect.Reset(client)
+	case *events.LoggedOut:
+		reconnect.Stop(client)
+	}
+}
+
 func HandleConnectionEvents(senderJid string, publisher messaging.AMQPPublisherInterface, logger provider.ILogger, eventBuilder *model.EventBuilder, stream proto.WaCoreGateway_StreamConnectDeviceServer, ctx context.Context, evt interface{}) {
 	queueName := util.Configuration.Queues.EventHandlerQueue
 	queueEvent := &model.QueueEvent{}
@@ -56,6 +157,7 @@ func HandleConnectionEvents(senderJid string, publisher messaging.AMQPPublisherI
 			streamData := &proto.EventResponse{
 				Type: "event",
 				Desc: "Pairing successfully",
+				Jid:  v.ID.String(),
 			}
 			if stream != nil {
 				if err := stream.Send(streamData); err != nil {
@@ -92,12 +194,13 @@ func HandleConnectionEvents(senderJid string, publisher messaging.AMQPPublisherI
 
 }
 
-func HandleMessageEvents(senderJid string, publisher messaging.AMQPPublisherInterface, logger provider.ILogger, eventBuilder *model.EventBuilder, ctx context.Context, evt interface{}) {
+func HandleMessageEvents(senderJid string, publisher messaging.AMQPPublisherInterface, logger provider.ILogger, media *MediaDownloader, polls *cache.PollCache, client *whatsmeow.Client, eventBuilder *model.EventBuilder, ctx context.Context, evt interface{}) {
 	queueEvent := &model.QueueEvent{}
 
 	switch v := evt.(type) {
 	case *events.Receipt:
 		logger.Infofctx(provider.AppLog, ctx, "Receipt for message ID %v from %s", v.MessageIDs, v.Sender.String())
+		metrics.ObserveReceipt(receiptType(v.Type))
 
 		// Create and publish receipt event
 		queueEvent = eventBuilder.CreateReceiptEvent(v.MessageIDs, v.Sender.String(), string(v.Type), v.Timestamp.Unix())
@@ -111,11 +214,40 @@ func HandleMessageEvents(senderJid string, publisher messaging.AMQPPublisherInte
 		return
 
 	case *events.Message:
+		metrics.ObserveMessageReceived(inboundMessageType(v))
+		if v.Message.GetPollUpdateMessage() != nil {
+			HandlePollVote(publisher, logger, polls, client, eventBuilder, ctx, v)
+			return
+		}
+		if pollMsg := model.GetPollCreationMessage(v.Message); pollMsg != nil {
+			options := make([]string, 0, len(pollMsg.GetOptions()))
+			for _, option := range pollMsg.GetOptions() {
+				options = append(options, option.GetOptionName())
+			}
+			polls.Set(v.Info.ID, options)
+		}
+
 		sender := v.Info.Sender.String()
 		content := v.Message.GetConversation()
 
 		// Use the generic message event creator which handles all message types
-		queueEvent = eventBuilder.CreateGenericMessageEvent(v)
+		queueEvent = eventBuilder.CreateGenericMessageEvent(v, nil)
+
+		// Revokes and edits arrive as protocol messages referring to an earlier message
+		if protocolMsg := v.Message.GetProtocolMessage(); protocolMsg != nil {
+			switch protocolMsg.GetType() {
+			case waProto.ProtocolMessage_REVOKE:
+				queueEvent = eventBuilder.CreateMessageRevokedEvent(protocolMsg.GetKey().GetID(), v.Info.Chat.String(), sender, v.Info.IsFromMe)
+
+			case waProto.ProtocolMessage_MESSAGE_EDIT:
+				edited := protocolMsg.GetEditedMessage()
+				text := edited.GetConversation()
+				if text == "" {
+					text = edited.GetExtendedTextMessage().GetText()
+				}
+				queueEvent = eventBuilder.CreateMessageEditedEvent(protocolMsg.GetKey().GetID(), v.Info.Chat.String(), sender, v.Info.IsFromMe, text, edited)
+			}
+		}
 
 		logger.Infofctx(provider.AppLog, ctx, "New message from %s: %s", sender, content)
 
@@ -136,6 +268,9 @@ func HandleMessageEvents(senderJid string, publisher messaging.AMQPPublisherInte
 		case msg.GetVideoMessage() != nil:
 			logger.Debugfctx(provider.AppLog, ctx, "Video with caption: %s", msg.GetVideoMessage().GetCaption())
 
+		case msg.GetStickerMessage() != nil:
+			logger.Debugfctx(provider.AppLog, ctx, "Sticker animated: %t", msg.GetStickerMessage().GetIsAnimated())
+
 		case msg.GetButtonsResponseMessage() != nil:
 			logger.Debugfctx(provider.AppLog, ctx, "Button clicked: %s", msg.GetButtonsResponseMessage().GetSelectedButtonID())
 
@@ -151,6 +286,19 @@ func HandleMessageEvents(senderJid string, publisher messaging.AMQPPublisherInte
 		}
 
 		queueName := util.Configuration.Queues.MessagesEventQueue
+
+		// Downloading can take a while, publish once the media is stored without blocking the handler
+		if downloadable, mimeType, ok := media.Downloadable(msg); ok {
+			media.DownloadAsync(ctx, client, v, downloadable, mimeType, func(stored *model.StoredMedia) {
+				queueEvent := eventBuilder.CreateGenericMessageEvent(v, stored)
+				err := publisher.Publish(ctx, queueName, queueEvent, func(options *messaging.AMQPPublisherOptions) {})
+				if err != nil {
+					logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish message event: %v", err)
+				}
+			})
+			return
+		}
+
 		err := publisher.Publish(ctx, queueName, queueEvent, func(options *messaging.AMQPPublisherOptions) {})
 		if err != nil {
 			logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish message event: %v", err)
@@ -163,6 +311,57 @@ func HandleMessageEvents(senderJid string, publisher messaging.AMQPPublisherInte
 	}
 }
 
+// receiptType names the receipt type, delivery receipts have an empty type
+func receiptType(t types.ReceiptType) string {
+	if t == types.ReceiptTypeDelivered {
+		return "delivered"
+	}
+	return string(t)
+}
+
+// inboundMessageType returns the media type of a media message, or the message type otherwise
+func inboundMessageType(evt *events.Message) string {
+	if evt.Info.MediaType != "" {
+		return evt.Info.MediaType
+	}
+	return evt.Info.Type
+}
+
+// HandlePollVote decrypts a poll vote and publishes the selected option names
+func HandlePollVote(publisher messaging.AMQPPublisherInterface, logger provider.ILogger, polls *cache.PollCache, client *whatsmeow.Client, eventBuilder *model.EventBuilder, ctx context.Context, evt *events.Message) {
+	pollID := evt.Message.GetPollUpdateMessage().GetPollCreationMessageKey().GetID()
+
+	vote, err := client.DecryptPollVote(ctx, evt)
+	if err != nil {
+		logger.Errorfctx(provider.AppLog, ctx, false, "Failed to decrypt poll vote for poll %s: %v", pollID, err)
+		return
+	}
+
+	// Votes only carry SHA-256 hashes of the option names
+	options := polls.Get(pollID)
+	optionByHash := make(map[string]string, len(options))
+	for i, hash := range whatsmeow.HashPollOptions(options) {
+		optionByHash[string(hash)] = options[i]
+	}
+
+	selected := make([]string, 0, len(vote.GetSelectedOptions()))
+	var unknown []string
+	for _, hash := range vote.GetSelectedOptions() {
+		if option, ok := optionByHash[string(hash)]; ok {
+			selected = append(selected, option)
+		} else {
+			unknown = append(unknown, hex.EncodeToString(hash))
+		}
+	}
+	logger.Infofctx(provider.AppLog, ctx, "Poll vote for %s from %s: %v", pollID, evt.Info.Sender.String(), selected)
+
+	queueEvent := eventBuilder.CreatePollVoteEvent(pollID, evt.Info.Chat.String(), evt.Info.Sender.String(), selected, unknown, evt.Info.Timestamp.Unix())
+	err = publisher.Publish(ctx, util.Configuration.Queues.MessagesEventQueue, queueEvent, func(options *messaging.AMQPPublisherOptions) {})
+	if err != nil {
+		logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish poll vote event: %v", err)
+	}
+}
+
 func HandleAnyEvents(senderJid string, publisher messaging.AMQPPublisherInterface, logger provider.ILogger, eventBuilder *model.EventBuilder, ctx context.Context, evt interface{}) {
 	queueName := util.Configuration.Queues.EventHandlerQueue
 	queueEvent := &model.QueueEvent{}
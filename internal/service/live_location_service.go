@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"wacoregateway/internal/cache"
+	"wacoregateway/internal/metrics"
+	proto "wacoregateway/model/pb"
+
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultLiveLocationDuration is used when the caller does not set a duration
+const DefaultLiveLocationDuration = 15 * time.Minute
+
+const (
+	LiveLocationStopped = "stopped"
+	LiveLocationExpired = "expired"
+	LiveLocationClosed  = "closed"
+)
+
+// startLiveLocation registers a live location share so later updates can be streamed to it
+func (s *service) startLiveLocation(shareID string, req *proto.MessagePayload) {
+	duration := time.Duration(req.LiveLocation.Duration) * time.Second
+	if duration == 0 {
+		duration = DefaultLiveLocationDuration
+	}
+
+	s.shares.Set(shareID, &cache.LiveLocationShare{
+		SenderJID: req.SenderJid,
+		To:        req.To,
+		StartedAt: time.Now(),
+		Duration:  duration,
+	})
+	time.AfterFunc(duration, func() {
+		s.shares.Delete(shareID)
+	})
+}
+
+func (s *service) ProcessStreamLiveLocation(ctx context.Context, stream proto.WaCoreGateway_StreamLiveLocationServer) error {
+	updates := make(chan *proto.LiveLocationUpdate)
+	recvErr := make(chan error, 1)
+
+	go func() {
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		shareID  string
+		sequence int64
+		expired  <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+
+		case err := <-recvErr:
+			if err == io.EOF {
+				return stream.SendAndClose(&proto.LiveLocationResponse{ShareId: shareID, Sequence: sequence, Reason: LiveLocationClosed})
+			}
+			return err
+
+		case <-expired:
+			s.shares.Delete(shareID)
+			return stream.SendAndClose(&proto.LiveLocationResponse{ShareId: shareID, Sequence: sequence, Reason: LiveLocationExpired})
+
+		case update := <-updates:
+			if update.ShareId == "" {
+				return status.Errorf(codes.InvalidArgument, "share_id param cannot be empty")
+			}
+
+			if shareID == "" {
+				share := s.shares.Get(update.ShareId)
+				if share == nil {
+					return status.Errorf(codes.NotFound, "live location %s not found or already expired", update.ShareId)
+				}
+				if senderJID, _ := s.clients.Resolve(update.SenderJid); share.SenderJID != senderJID {
+					return status.Errorf(codes.PermissionDenied, "live location %s does not belong to %s", update.ShareId, update.SenderJid)
+				}
+
+				timer := time.NewTimer(time.Until(share.ExpiresAt()))
+				defer timer.Stop()
+				expired = timer.C
+				shareID = update.ShareId
+			} else if update.ShareId != shareID {
+				return status.Errorf(codes.InvalidArgument, "a stream can only update live location %s", shareID)
+			}
+
+			if update.Stop {
+				s.shares.Delete(shareID)
+				return stream.SendAndClose(&proto.LiveLocationResponse{ShareId: shareID, Sequence: sequence, Reason: LiveLocationStopped})
+			}
+
+			seq, err := s.sendLiveLocationUpdate(ctx, shareID, update)
+			if err != nil {
+				return err
+			}
+			sequence = seq
+		}
+	}
+}
+
+// sendLiveLocationUpdate sends the next coordinates of an active live location share
+func (s *service) sendLiveLocationUpdate(ctx context.Context, shareID string, update *proto.LiveLocationUpdate) (int64, error) {
+	share := s.shares.Get(shareID)
+	if share == nil || time.Now().After(share.ExpiresAt()) {
+		return 0, status.Errorf(codes.NotFound, "live location %s not found or already expired", shareID)
+	}
+
+	_, client := s.clients.Resolve(share.SenderJID)
+	if client == nil {
+		return 0, status.Errorf(codes.NotFound, "sender device with JID %s not found", share.SenderJID)
+	}
+
+	jid, err := types.ParseJID(share.To)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid recipient JID: %v", err)
+	}
+
+	sequence, ok := s.shares.NextSequence(shareID)
+	if !ok {
+		return 0, status.Errorf(codes.NotFound, "live location %s not found or already expired", shareID)
+	}
+
+	msg := &waProto.Message{
+		LiveLocationMessage: &waProto.LiveLocationMessage{
+			DegreesLatitude:                   protoFloat(update.Latitude),
+			DegreesLongitude:                  protoFloat(update.Longitude),
+			AccuracyInMeters:                  protoUint32(update.Accuracy),
+			SpeedInMps:                        protoFloat32(update.Speed),
+			DegreesClockwiseFromMagneticNorth: protoUint32(update.Heading),
+			SequenceNumber:                    protoInt64(sequence),
+			TimeOffset:                        protoUint32(uint32(time.Since(share.StartedAt).Seconds())),
+		},
+	}
+
+	resp, err := client.SendMessage(ctx, jid, msg)
+	metrics.ObserveMessageSent(LiveLocation, err)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to send live location update: %v", err)
+	}
+
+	s.publishOutboundMessage(ctx, share.SenderJID, resp.ID, LiveLocation, share.To, msg)
+
+	return sequence, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"wacoregateway/internal/inflight"
+	"wacoregateway/internal/provider"
+	"wacoregateway/internal/provider/blobstore"
+	"wacoregateway/model"
+	"wacoregateway/util"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MediaDownloader decrypts inbound media and persists it in a blob store according to the download policy
+type MediaDownloader struct {
+	store   blobstore.Store
+	logger  provider.ILogger
+	types   []string
+	maxSize uint64
+	timeout time.Duration
+	sem     chan struct{}
+	pending inflight.Tracker
+}
+
+func NewMediaDownloader(store blobstore.Store, logger provider.ILogger) *MediaDownloader {
+	cfg := util.Configuration.Media.Download
+
+	concurrent := cfg.Concurrent
+	if concurrent <= 0 {
+		concurrent = 1
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+
+	return &MediaDownloader{
+		store:   store,
+		logger:  logger,
+		types:   cfg.Types,
+		maxSize: uint64(cfg.MaxSize) * 1024 * 1024,
+		timeout: timeout,
+		sem:     make(chan struct{}, concurrent),
+	}
+}
+
+// Downloadable returns the media part of msg and its mime type when the policy allows downloading it.
+// A nil MediaDownloader never downloads anything.
+func (m *MediaDownloader) Downloadable(msg *waProto.Message) (whatsmeow.DownloadableMessage, string, bool) {
+	if m == nil {
+		return nil, "", false
+	}
+
+	var (
+		media       whatsmeow.DownloadableMessage
+		messageType model.MessageType
+		mimeType    string
+		fileLength  uint64
+	)
+	switch {
+	case msg.GetImageMessage() != nil:
+		media, messageType = msg.GetImageMessage(), model.MessageTypeImage
+		mimeType, fileLength = msg.GetImageMessage().GetMimetype(), msg.GetImageMessage().GetFileLength()
+	case msg.GetAudioMessage() != nil:
+		media, messageType = msg.GetAudioMessage(), model.MessageTypeAudio
+		mimeType, fileLength = msg.GetAudioMessage().GetMimetype(), msg.GetAudioMessage().GetFileLength()
+	case msg.GetVideoMessage() != nil:
+		media, messageType = msg.GetVideoMessage(), model.MessageTypeVideo
+		mimeType, fileLength = msg.GetVideoMessage().GetMimetype(), msg.GetVideoMessage().GetFileLength()
+	case msg.GetDocumentMessage() != nil:
+		media, messageType = msg.GetDocumentMessage(), model.MessageTypeDocument
+		mimeType, fileLength = msg.GetDocumentMessage().GetMimetype(), msg.GetDocumentMessage().GetFileLength()
+	case msg.GetStickerMessage() != nil:
+		media, messageType = msg.GetStickerMessage(), model.MessageTypeSticker
+		mimeType, fileLength = msg.GetStickerMessage().GetMimetype(), msg.GetStickerMessage().GetFileLength()
+	default:
+		return nil, "", false
+	}
+
+	if !slices.Contains(m.types, string(messageType)) {
+		return nil, "", false
+	}
+	if m.maxSize > 0 && fileLength > m.maxSize {
+		return nil, "", false
+	}
+
+	return media, mimeType, true
+}
+
+// Download decrypts the media of evt and stores it. Failures are reported in StoredMedia.MediaError
+// so the message event can still be published.
+func (m *MediaDownloader) Download(ctx context.Context, client *whatsmeow.Client, evt *events.Message, media whatsmeow.DownloadableMessage, mimeType string) *model.StoredMedia {
+	m.sem <- struct{}{}
+	defer func() { <-m.sem }()
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "whatsapp.Download", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("message.id", evt.Info.ID), attribute.String("media.mimetype", mimeType)))
+	var err error
+	defer func() { endSpan(span, err) }()
+
+	data, err := client.Download(ctx, media)
+	if err != nil {
+		m.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to download media of message %s: %v", evt.Info.ID, err)
+		return &model.StoredMedia{MediaError: err.Error()}
+	}
+
+	sum := sha256.Sum256(data)
+	key := path.Join(client.Store.ID.User, mediaObjectName(evt.Info.ID)+mediaExtension(mimeType, data))
+
+	object, err := m.store.Put(ctx, key, data, mimeType)
+	if err != nil {
+		m.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to store media of message %s: %v", evt.Info.ID, err)
+		return &model.StoredMedia{SHA256: hex.EncodeToString(sum[:]), MediaError: err.Error()}
+	}
+	m.logger.Debugfctx(provider.AppLog, ctx, "Stored media of message %s as %s", evt.Info.ID, object.Ref)
+
+	return &model.StoredMedia{
+		MediaRef:  object.Ref,
+		LocalPath: object.Path,
+		SHA256:    hex.EncodeToString(sum[:]),
+	}
+}
+
+// DownloadAsync downloads the media of evt in the background and passes the result to done
+func (m *MediaDownloader) DownloadAsync(ctx context.Context, client *whatsmeow.Client, evt *events.Message, media whatsmeow.DownloadableMessage, mimeType string, done func(stored *model.StoredMedia)) {
+	if !m.pending.Start() {
+		done(&model.StoredMedia{MediaError: "media downloader is shutting down"})
+		return
+	}
+	go func() {
+		defer m.pending.Done()
+		done(m.Download(ctx, client, evt, media, mimeType))
+	}()
+}
+
+// Wait refuses new background downloads and waits until the running ones are done or ctx is done. A nil MediaDownloader has nothing to wait for.
+func (m *MediaDownloader) Wait(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+
+	return m.pending.Drain(ctx)
+}
+
+// mediaObjectName returns the message ID when it is alphanumeric and its hash otherwise,
+// the ID comes from the sender and must not be able to choose where the media is stored
+func mediaObjectName(messageID string) string {
+	if messageID != "" && strings.IndexFunc(messageID, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) == -1 {
+		return messageID
+	}
+	sum := sha256.Sum256([]byte(messageID))
+	return hex.EncodeToString(sum[:])
+}
+
+// mediaExtension returns the file extension for the declared mime type, sniffing the data when it is unknown
+func mediaExtension(mimeType string, data []byte) string {
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		if known := mimetype.Lookup(mediaType); known != nil {
+			return known.Extension()
+		}
+	}
+	return mimetype.Detect(data).Extension()
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestMediaObjectName(t *testing.T) {
+	tests := []struct {
+		messageID string
+		kept      bool
+	}{
+		{"3EB0C767D26A1D6F", true},
+		{"ABCdef123", true},
+		{"", false},
+		{"../../etc/passwd", false},
+		{"..", false},
+		{"a/b", false},
+		{"/abs", false},
+		{`a\b`, false},
+		{"ABC.jpg", false},
+		{"ABC DEF", false},
+	}
+	for _, tt := range tests {
+		name := mediaObjectName(tt.messageID)
+		if tt.kept && name != tt.messageID {
+			t.Errorf("mediaObjectName(%q) = %q, want the message ID", tt.messageID, name)
+		}
+		if !tt.kept && (name == tt.messageID || len(name) != 64) {
+			t.Errorf("mediaObjectName(%q) = %q, want its sha256 hex", tt.messageID, name)
+		}
+		if strings.ContainsAny(name, `/\.`) || path.Clean(name) != name {
+			t.Errorf("mediaObjectName(%q) = %q is not a single path element", tt.messageID, name)
+		}
+	}
+
+	if mediaObjectName("a/b") == mediaObjectName("a/c") {
+		t.Error("different message IDs share an object name")
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"wacoregateway/util"
+
+	"github.com/gabriel-vasile/mimetype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errBlockedAddress = errors.New("address is not allowed")
+
+// carrierGradeNAT is the shared address space (RFC 6598) which net.IP.IsPrivate does not cover
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// LoadedMedia is media loaded from an outbound message source
+type LoadedMedia struct {
+	Data     []byte
+	MimeType string // sniffed from the data
+}
+
+// MediaLoader loads outbound media from http(s) URLs, allow-listed local directories and inline data
+type MediaLoader struct {
+	client      *http.Client
+	maxSize     int64
+	allowedDirs []string
+}
+
+func NewMediaLoader() *MediaLoader {
+	cfg := util.Configuration.Media.Source
+
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	maxSize := int64(cfg.MaxSize) * 1024 * 1024
+	if maxSize <= 0 {
+		maxSize = 64 * 1024 * 1024
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if cfg.BlockPrivateIPs {
+		// Checked on the resolved address of every connection, so redirects and DNS rebinding are covered too
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isBlockedIP(ip) {
+				return fmt.Errorf("%w: %s", errBlockedAddress, host)
+			}
+			return nil
+		}
+	}
+
+	var allowedDirs []string
+	for _, dir := range cfg.AllowedDirs {
+		if abs, err := filepath.Abs(dir); err == nil {
+			if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+				abs = resolved
+			}
+			allowedDirs = append(allowedDirs, abs)
+		}
+	}
+
+	return &MediaLoader{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+			},
+		},
+		maxSize:     maxSize,
+		allowedDirs: allowedDirs,
+	}
+}
+
+// Load reads media from source, which is an http(s) URL, a data: URI, a base64: prefixed payload,
+// a file:// URL or a path inside one of the allowed directories
+func (l *MediaLoader) Load(ctx context.Context, source string) (*LoadedMedia, error) {
+	if source == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "media url param cannot be empty")
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch {
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		data, err = l.loadHTTP(ctx, source)
+	case strings.HasPrefix(source, "data:"):
+		data, err = l.loadDataURI(source)
+	case strings.HasPrefix(source, "base64:"):
+		data, err = l.decodeBase64(strings.TrimPrefix(source, "base64:"))
+	case strings.HasPrefix(source, "file://"):
+		data, err = l.loadFile(strings.TrimPrefix(source, "file://"))
+	default:
+		data, err = l.loadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoadedMedia{
+		Data:     data,
+		MimeType: mimetype.Detect(data).String(),
+	}, nil
+}
+
+func (l *MediaLoader) loadHTTP(ctx context.Context, source string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid media url: %v", err)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		if errors.Is(err, errBlockedAddress) {
+			return nil, status.Errorf(codes.PermissionDenied, "media url is not allowed: %v", err)
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to get media: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to get media: unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength > l.maxSize {
+		return nil, l.tooLarge()
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, l.maxSize+1))
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed get bytes the media: %v", err)
+	}
+	if int64(len(data)) > l.maxSize {
+		return nil, l.tooLarge()
+	}
+	return data, nil
+}
+
+// loadDataURI decodes data:[<mediatype>][;base64],<data>
+func (l *MediaLoader) loadDataURI(source string) ([]byte, error) {
+	header, payload, found := strings.Cut(strings.TrimPrefix(source, "data:"), ",")
+	if !found {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid data uri")
+	}
+	if strings.HasSuffix(header, ";base64") {
+		return l.decodeBase64(payload)
+	}
+
+	decoded, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid data uri: %v", err)
+	}
+	if int64(len(decoded)) > l.maxSize {
+		return nil, l.tooLarge()
+	}
+	return []byte(decoded), nil
+}
+
+func (l *MediaLoader) decodeBase64(payload string) ([]byte, error) {
+	if int64(base64.StdEncoding.DecodedLen(len(payload))) > l.maxSize+2 {
+		return nil, l.tooLarge()
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid base64 media: %v", err)
+	}
+	if int64(len(data)) > l.maxSize {
+		return nil, l.tooLarge()
+	}
+	return data, nil
+}
+
+// loadFile reads a local file, only when it resolves to a path inside an allowed directory
+func (l *MediaLoader) loadFile(path string) ([]byte, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid media path: %v", err)
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed get local media: %v", err)
+	}
+	if !l.isAllowedPath(resolved) {
+		return nil, status.Errorf(codes.PermissionDenied, "local media path %s is not in an allowed directory", path)
+	}
+
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "failed get local media: %v", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, status.Errorf(codes.InvalidArgument, "local media path %s is not a file", path)
+	}
+	if info.Size() > l.maxSize {
+		return nil, l.tooLarge()
+	}
+
+	data, err := os.ReadFile(resolved)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed get local media: %v", err)
+	}
+	return data, nil
+}
+
+func (l *MediaLoader) isAllowedPath(path string) bool {
+	for _, dir := range l.allowedDirs {
+		rel, err := filepath.Rel(dir, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *MediaLoader) tooLarge() error {
+	return status.Errorf(codes.InvalidArgument, "media exceeds the maximum size of %d bytes", l.maxSize)
+}
+
+func isBlockedIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		carrierGradeNAT.Contains(ip)
+}
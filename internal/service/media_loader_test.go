@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsBlockedIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"100.64.0.1", true},
+		{"100.127.255.254", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"fc00::1", true},
+		{"0.0.0.0", true},
+		{"224.0.0.1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:10.0.0.1", true},
+		{"::ffff:169.254.169.254", true},
+		{"::ffff:100.64.0.1", true},
+		{"8.8.8.8", false},
+		{"100.128.0.1", false},
+		{"2001:4860:4860::8888", false},
+		{"::ffff:8.8.8.8", false},
+	}
+	for _, tt := range tests {
+		if got := isBlockedIP(net.ParseIP(tt.ip)); got != tt.blocked {
+			t.Errorf("isBlockedIP(%s) = %v, want %v", tt.ip, got, tt.blocked)
+		}
+	}
+}
+
+func TestLoadFileAllowedDirs(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := filepath.Join(root, "allowed")
+	outside := filepath.Join(root, "outside")
+	for _, dir := range []string{allowed, outside} {
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for path, content := range map[string]string{
+		filepath.Join(allowed, "image.png"): "inside",
+		filepath.Join(allowed, "..image"):   "dotted name",
+		filepath.Join(outside, "secret"):    "outside",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret"), filepath.Join(allowed, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(allowed, "dirlink")); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := &MediaLoader{maxSize: 1024, allowedDirs: []string{allowed}}
+	tests := []struct {
+		name   string
+		source string
+		want   codes.Code
+	}{
+		{"file in allowed dir", filepath.Join(allowed, "image.png"), codes.OK},
+		{"file url", "file://" + filepath.Join(allowed, "image.png"), codes.OK},
+		{"name starting with dots", filepath.Join(allowed, "..image"), codes.OK},
+		{"dot dot escape", filepath.Join(allowed, "..", "outside", "secret"), codes.PermissionDenied},
+		{"unclean dot dot escape", allowed + "/../outside/secret", codes.PermissionDenied},
+		{"symlink to file outside", filepath.Join(allowed, "link"), codes.PermissionDenied},
+		{"symlink to dir outside", filepath.Join(allowed, "dirlink", "secret"), codes.PermissionDenied},
+		{"allowed dir itself", allowed, codes.InvalidArgument},
+		{"missing file", filepath.Join(allowed, "missing"), codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loader.Load(context.Background(), tt.source)
+			if code := status.Code(err); code != tt.want {
+				t.Errorf("Load(%s) code = %v, want %v (%v)", tt.source, code, tt.want, err)
+			}
+		})
+	}
+
+	if (&MediaLoader{}).isAllowedPath(filepath.Join(allowed, "image.png")) {
+		t.Error("isAllowedPath without allowed dirs = true, want false")
+	}
+}
+
+func TestLoadInlineSize(t *testing.T) {
+	loader := &MediaLoader{maxSize: 16}
+	fits := strings.Repeat("a", 16)
+	oversized := strings.Repeat("a", 17)
+
+	tests := []struct {
+		name   string
+		source string
+		want   codes.Code
+	}{
+		{"base64 at the limit", "base64:" + base64.StdEncoding.EncodeToString([]byte(fits)), codes.OK},
+		{"base64 over the limit", "base64:" + base64.StdEncoding.EncodeToString([]byte(oversized)), codes.InvalidArgument},
+		{"huge base64 rejected before decoding", "base64:" + strings.Repeat("A", 1<<20), codes.InvalidArgument},
+		{"invalid base64", "base64:!!!!", codes.InvalidArgument},
+		{"base64 data uri at the limit", "data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte(fits)), codes.OK},
+		{"base64 data uri over the limit", "data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte(oversized)), codes.InvalidArgument},
+		{"plain data uri at the limit", "data:text/plain," + fits, codes.OK},
+		{"plain data uri over the limit", "data:text/plain," + oversized, codes.InvalidArgument},
+		{"escaped data uri at the limit", "data:," + strings.Repeat("%41", 16), codes.OK},
+		{"data uri without payload", "data:text/plain", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			media, err := loader.Load(context.Background(), tt.source)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("Load code = %v, want %v (%v)", code, tt.want, err)
+			}
+			if err == nil && int64(len(media.Data)) > loader.maxSize {
+				t.Errorf("Load returned %d bytes, more than the maximum of %d", len(media.Data), loader.maxSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"wacoregateway/internal/cache"
+	"wacoregateway/internal/metrics"
+	proto "wacoregateway/model/pb"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MediaHandleTTL is how long an uploaded media handle can be referenced by SendMessage
+const MediaHandleTTL = 24 * time.Hour
+
+// uploadMediaTypes maps message types carrying media to the WhatsApp media type they are uploaded as
+var uploadMediaTypes = map[string]whatsmeow.MediaType{
+	Image:    whatsmeow.MediaImage,
+	Video:    whatsmeow.MediaVideo,
+	Audio:    whatsmeow.MediaAudio,
+	Document: whatsmeow.MediaDocument,
+	Sticker:  whatsmeow.MediaImage,
+}
+
+// uploadedMedia is media uploaded to WhatsApp, ready to be referenced by a message
+type uploadedMedia struct {
+	whatsmeow.UploadResponse
+	MimeType string
+	FileName string
+	Width    uint32
+	Height   uint32
+	Animated bool
+}
+
+// prepareMedia reuses the upload referenced by handle, or loads media from source and uploads it
+func (s *service) prepareMedia(ctx context.Context, client *whatsmeow.Client, req *proto.MessagePayload, source, handle string) (*uploadedMedia, error) {
+	if handle != "" {
+		media := s.handles.Get(handle)
+		if media == nil {
+			return nil, status.Errorf(codes.NotFound, "media handle %s not found or expired", handle)
+		}
+		if media.SenderJID != req.SenderJid {
+			return nil, status.Errorf(codes.PermissionDenied, "media handle %s does not belong to %s", handle, req.SenderJid)
+		}
+		if media.MediaType != req.Type {
+			return nil, status.Errorf(codes.InvalidArgument, "media handle %s was uploaded as %s, not %s", handle, media.MediaType, req.Type)
+		}
+		return &uploadedMedia{
+			UploadResponse: media.Upload,
+			MimeType:       media.MimeType,
+			FileName:       media.FileName,
+			Width:          media.Width,
+			Height:         media.Height,
+			Animated:       media.Animated,
+		}, nil
+	}
+
+	media, err := s.loader.Load(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	return uploadBytes(ctx, client, req.Type, media.Data, media.MimeType)
+}
+
+// uploadBytes uploads data to WhatsApp as the media type used by messageType
+func uploadBytes(ctx context.Context, client *whatsmeow.Client, messageType string, data []byte, mimeType string) (*uploadedMedia, error) {
+	mediaType, ok := uploadMediaTypes[messageType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported media type %s", messageType)
+	}
+
+	result := &uploadedMedia{MimeType: mimeType}
+	if messageType == Sticker {
+		webp, err := parseWebP(data)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid sticker: %v", err)
+		}
+		result.MimeType = "image/webp"
+		result.Width, result.Height, result.Animated = webp.Width, webp.Height, webp.Animated
+	}
+
+	ctx, span := tracer.Start(ctx, "whatsapp.Upload", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("media.type", messageType), attribute.Int("media.size", len(data))))
+	start := time.Now()
+	uploaded, err := client.Upload(ctx, data, mediaType)
+	metrics.ObserveMediaUpload(messageType, len(data), start, err)
+	endSpan(span, err)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed upload %s to whatsapp: %v", messageType, err)
+	}
+	result.UploadResponse = uploaded
+
+	return result, nil
+}
+
+func (s *service) ProcessUploadMedia(ctx context.Context, stream proto.WaCoreGateway_UploadMediaServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return status.Errorf(codes.InvalidArgument, "first message must contain the upload metadata")
+	}
+	if meta.SenderJid == "" {
+		return status.Errorf(codes.PermissionDenied, "senderJID param cannot be empty")
+	}
+	if _, ok := uploadMediaTypes[meta.MediaType]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unsupported media type %s", meta.MediaType)
+	}
+
+	client, err := s.resolveSender(&meta.SenderJid)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetMetadata() != nil {
+			return status.Errorf(codes.InvalidArgument, "upload metadata can only be sent once")
+		}
+		if int64(buf.Len()+len(req.GetChunk())) > s.loader.maxSize {
+			return s.loader.tooLarge()
+		}
+		buf.Write(req.GetChunk())
+	}
+	if buf.Len() == 0 {
+		return status.Errorf(codes.InvalidArgument, "uploaded media is empty")
+	}
+
+	data := buf.Bytes()
+	uploaded, err := uploadBytes(ctx, client, meta.MediaType, data, mimeTypeOr(meta.Mimetype, mimetype.Detect(data).String()))
+	if err != nil {
+		return err
+	}
+
+	handle := uuid.New().String()
+	expiresAt := time.Now().Add(MediaHandleTTL)
+	s.handles.Set(handle, &cache.MediaHandle{
+		SenderJID: meta.SenderJid,
+		MediaType: meta.MediaType,
+		MimeType:  uploaded.MimeType,
+		FileName:  meta.Filename,
+		Width:     uploaded.Width,
+		Height:    uploaded.Height,
+		Animated:  uploaded.Animated,
+		Upload:    uploaded.UploadResponse,
+		ExpiresAt: expiresAt,
+	})
+
+	return stream.SendAndClose(&proto.UploadMediaResponse{
+		MediaHandle: handle,
+		Mimetype:    uploaded.MimeType,
+		FileLength:  uploaded.FileLength,
+		Sha256:      hex.EncodeToString(uploaded.FileSHA256),
+		ExpiresAt:   expiresAt.Unix(),
+	})
+}
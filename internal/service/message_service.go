@@ -3,12 +3,12 @@ package service
 import (
 	"context"
 	"errors"
-	"io"
-	"net/http"
-	"os"
+	"fmt"
+	"slices"
 	"strings"
 
 	"wacoregateway/internal/cache"
+	"wacoregateway/internal/metrics"
 	"wacoregateway/internal/provider"
 	"wacoregateway/model"
 	proto "wacoregateway/model/pb"
@@ -19,6 +19,8 @@ import (
 	// waProto "go.mau.fi/whatsmeow/binary/proto"
 	waProto "go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -30,13 +32,22 @@ const (
 	Document = "document"
 	Audio    = "audio"
 	Location = "location"
+	Contact  = "contact"
+	Contacts = "contacts"
+
+	LiveLocation = "live_location"
+	Poll         = "poll"
+	Sticker      = "sticker"
 )
 
+// MentionAll mentions every participant of a group
+const MentionAll = "@all"
+
 func (s *service) ProcessSendMessage(ctx context.Context, req *proto.MessagePayload) (*proto.MessageResponse, error) {
 
-	client := cache.GetClient(req.SenderJid)
-	if client == nil {
-		return nil, status.Errorf(codes.NotFound, "sender device with JID %s not found", req.SenderJid)
+	client, err := s.resolveSender(&req.SenderJid)
+	if err != nil {
+		return nil, err
 	}
 
 	jid, err := types.ParseJID(req.To)
@@ -44,128 +55,219 @@ func (s *service) ProcessSendMessage(ctx context.Context, req *proto.MessagePayl
 		return nil, status.Errorf(codes.InvalidArgument, "invalid recipient JID: %v", err)
 	}
 
+	contextInfo, err := buildContextInfo(client, jid, req)
+	if err != nil {
+		return nil, err
+	}
+
 	var msg *waProto.Message
 
 	switch req.Type {
 
 	case Text:
-		msg = &waProto.Message{
-			Conversation: protoStr(req.Text),
-		}
-
-	case Image:
-		url := req.Image.Url
-		var data []byte
-
-		if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
-			resp, err := http.Get(url)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to get image %v", err)
-			}
-			defer resp.Body.Close()
-
-			data, err = io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed get bytes the image: %v", err)
+		if contextInfo != nil {
+			msg = &waProto.Message{
+				ExtendedTextMessage: &waProto.ExtendedTextMessage{
+					Text:        protoStr(req.Text),
+					ContextInfo: contextInfo,
+				},
 			}
 		} else {
-			data, err = os.ReadFile(req.Image.Url)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed get local image %v", err)
+			msg = &waProto.Message{
+				Conversation: protoStr(req.Text),
 			}
 		}
 
-		uploaded, err := client.Upload(context.Background(), data, whatsmeow.MediaImage)
+	case Image:
+		if req.Image == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "image param cannot be empty")
+		}
+		uploaded, err := s.prepareMedia(ctx, client, req, req.Image.Url, req.Image.MediaHandle)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed upload image to whatsapp: %v", err)
+			return nil, err
 		}
 		msg = &waProto.Message{
 			ImageMessage: &waProto.ImageMessage{
 				URL:           &uploaded.URL,
-				Mimetype:      protoStr(req.Image.Mimetype),
+				Mimetype:      protoStr(mimeTypeOr(req.Image.Mimetype, uploaded.MimeType)),
 				Caption:       protoStr(req.Image.Caption),
 				FileSHA256:    uploaded.FileSHA256,
 				FileEncSHA256: uploaded.FileEncSHA256,
 				MediaKey:      uploaded.MediaKey,
 				FileLength:    &uploaded.FileLength,
 				DirectPath:    protoStr(uploaded.DirectPath),
+				ContextInfo:   contextInfo,
 			},
 		}
 
 	case Video:
-		data, err := os.ReadFile(req.Video.Url)
-		if err != nil {
-			return nil, err
+		if req.Video == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "video param cannot be empty")
 		}
-		uploaded, err := client.Upload(context.Background(), data, whatsmeow.MediaVideo)
+		uploaded, err := s.prepareMedia(ctx, client, req, req.Video.Url, req.Video.MediaHandle)
 		if err != nil {
 			return nil, err
 		}
 		msg = &waProto.Message{
 			VideoMessage: &waProto.VideoMessage{
 				URL:           &uploaded.URL,
-				Mimetype:      protoStr(req.Video.Mimetype),
+				Mimetype:      protoStr(mimeTypeOr(req.Video.Mimetype, uploaded.MimeType)),
 				Caption:       protoStr(req.Video.Caption),
 				FileSHA256:    uploaded.FileSHA256,
 				FileEncSHA256: uploaded.FileEncSHA256,
 				MediaKey:      uploaded.MediaKey,
 				FileLength:    &uploaded.FileLength,
 				DirectPath:    protoStr(uploaded.DirectPath),
+				ContextInfo:   contextInfo,
 			},
 		}
 
 	case Audio:
-		data, err := os.ReadFile(req.Audio.Url)
-		if err != nil {
-			return nil, err
+		if req.Audio == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "audio param cannot be empty")
 		}
-		uploaded, err := client.Upload(context.Background(), data, whatsmeow.MediaAudio)
+		uploaded, err := s.prepareMedia(ctx, client, req, req.Audio.Url, req.Audio.MediaHandle)
 		if err != nil {
 			return nil, err
 		}
 		msg = &waProto.Message{
 			AudioMessage: &waProto.AudioMessage{
 				URL:           &uploaded.URL,
-				Mimetype:      protoStr(req.Audio.MimeType),
+				Mimetype:      protoStr(mimeTypeOr(req.Audio.MimeType, uploaded.MimeType)),
 				FileSHA256:    uploaded.FileSHA256,
 				FileEncSHA256: uploaded.FileEncSHA256,
 				MediaKey:      uploaded.MediaKey,
 				FileLength:    &uploaded.FileLength,
 				PTT:           protoBool(req.Audio.Ptt),
 				DirectPath:    protoStr(uploaded.DirectPath),
+				ContextInfo:   contextInfo,
 			},
 		}
 
 	case Document:
-		data, err := os.ReadFile(req.Document.Url)
-		if err != nil {
-			return nil, err
+		if req.Document == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "document param cannot be empty")
 		}
-		uploaded, err := client.Upload(context.Background(), data, whatsmeow.MediaDocument)
+		uploaded, err := s.prepareMedia(ctx, client, req, req.Document.Url, req.Document.MediaHandle)
 		if err != nil {
 			return nil, err
 		}
+		filename := req.Document.Filename
+		if filename == "" {
+			filename = uploaded.FileName
+		}
 		msg = &waProto.Message{
 			DocumentMessage: &waProto.DocumentMessage{
 				URL:           &uploaded.URL,
-				Mimetype:      protoStr(req.Document.Mimetype),
-				FileName:      protoStr(req.Document.Filename),
+				Mimetype:      protoStr(mimeTypeOr(req.Document.Mimetype, uploaded.MimeType)),
+				FileName:      protoStr(filename),
 				FileSHA256:    uploaded.FileSHA256,
 				FileEncSHA256: uploaded.FileEncSHA256,
 				MediaKey:      uploaded.MediaKey,
 				FileLength:    &uploaded.FileLength,
 				Title:         protoStr(req.Document.Title),
 				DirectPath:    protoStr(uploaded.DirectPath),
+				ContextInfo:   contextInfo,
+			},
+		}
+
+	case Sticker:
+		if req.Sticker == nil || (req.Sticker.Url == "" && req.Sticker.MediaHandle == "") {
+			return nil, status.Errorf(codes.InvalidArgument, "sticker url param cannot be empty")
+		}
+		uploaded, err := s.prepareMedia(ctx, client, req, req.Sticker.Url, req.Sticker.MediaHandle)
+		if err != nil {
+			return nil, err
+		}
+		msg = &waProto.Message{
+			StickerMessage: &waProto.StickerMessage{
+				URL:           &uploaded.URL,
+				Mimetype:      protoStr(uploaded.MimeType),
+				FileSHA256:    uploaded.FileSHA256,
+				FileEncSHA256: uploaded.FileEncSHA256,
+				MediaKey:      uploaded.MediaKey,
+				FileLength:    &uploaded.FileLength,
+				DirectPath:    protoStr(uploaded.DirectPath),
+				Width:         protoUint32(uploaded.Width),
+				Height:        protoUint32(uploaded.Height),
+				IsAnimated:    protoBool(uploaded.Animated),
+				ContextInfo:   contextInfo,
 			},
 		}
 
 	case Location:
+		if req.Location == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "location param cannot be empty")
+		}
 		msg = &waProto.Message{
 			LocationMessage: &waProto.LocationMessage{
 				DegreesLatitude:  protoFloat(req.Location.Latitude),
 				DegreesLongitude: protoFloat(req.Location.Longitude),
 				Name:             protoStr(req.Location.Name),
 				Address:          protoStr(req.Location.Address),
+				ContextInfo:      contextInfo,
+			},
+		}
+
+	case LiveLocation:
+		if req.LiveLocation == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "live_location param cannot be empty")
+		}
+		msg = &waProto.Message{
+			LiveLocationMessage: &waProto.LiveLocationMessage{
+				DegreesLatitude:  protoFloat(req.LiveLocation.Latitude),
+				DegreesLongitude: protoFloat(req.LiveLocation.Longitude),
+				AccuracyInMeters: protoUint32(req.LiveLocation.Accuracy),
+				Caption:          protoStr(req.LiveLocation.Caption),
+				SequenceNumber:   protoInt64(0),
+				TimeOffset:       protoUint32(0),
+				ContextInfo:      contextInfo,
+			},
+		}
+
+	case Poll:
+		if req.Poll == nil || req.Poll.Question == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "poll question param cannot be empty")
+		}
+		if len(req.Poll.Options) < 2 {
+			return nil, status.Errorf(codes.InvalidArgument, "poll needs at least 2 options")
+		}
+		if int(req.Poll.SelectableCount) > len(req.Poll.Options) {
+			return nil, status.Errorf(codes.InvalidArgument, "poll selectable_count cannot exceed the number of options")
+		}
+		msg = client.BuildPollCreation(req.Poll.Question, req.Poll.Options, int(req.Poll.SelectableCount))
+		msg.PollCreationMessage.ContextInfo = contextInfo
+
+	case Contact:
+		if req.Vcard == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "vcard param cannot be empty")
+		}
+		contact, err := buildContactMessage(req.Vcard)
+		if err != nil {
+			return nil, err
+		}
+		contact.ContextInfo = contextInfo
+		msg = &waProto.Message{
+			ContactMessage: contact,
+		}
+
+	case Contacts:
+		if req.Contacts == nil || len(req.Contacts.List) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "contacts param cannot be empty")
+		}
+		contacts := make([]*waProto.ContactMessage, 0, len(req.Contacts.List))
+		for _, c := range req.Contacts.List {
+			contact, err := buildContactMessage(c)
+			if err != nil {
+				return nil, err
+			}
+			contacts = append(contacts, contact)
+		}
+		msg = &waProto.Message{
+			ContactsArrayMessage: &waProto.ContactsArrayMessage{
+				DisplayName: protoStr(fmt.Sprintf("%d contacts", len(contacts))),
+				Contacts:    contacts,
+				ContextInfo: contextInfo,
 			},
 		}
 
@@ -173,27 +275,264 @@ func (s *service) ProcessSendMessage(ctx context.Context, req *proto.MessagePayl
 		return nil, errors.New("unsupported message type")
 	}
 
-	resp, err := client.SendMessage(context.Background(), jid, msg)
+	spanCtx, span := tracer.Start(ctx, "whatsapp.SendMessage", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("message.type", req.Type), attribute.String("message.to", jid.String())))
+	resp, err := client.SendMessage(spanCtx, jid, msg)
+	metrics.ObserveMessageSent(req.Type, err)
+	if err == nil {
+		span.SetAttributes(attribute.String("message.id", resp.ID))
+	}
+	endSpan(span, err)
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to send message: %v", err)
 	}
 
-	// Publish outbound message event to queue
-	eventBuilder := model.NewEventBuilder(req.SenderJid)
-	queueEvent := eventBuilder.CreateOutboundMessageEvent(resp.ID, req.Type, req.To, msg)
-	queueName := util.Configuration.Queues.MessagesEventQueue
+	s.sent.Set(resp.ID, &cache.SentMessage{
+		SenderJID: req.SenderJid,
+		Chat:      jid.String(),
+		SentAt:    resp.Timestamp,
+	})
 
-	err = s.publisher.Publish(ctx, queueName, queueEvent)
+	switch req.Type {
+	case LiveLocation:
+		s.startLiveLocation(resp.ID, req)
+	case Poll:
+		s.polls.Set(resp.ID, req.Poll.Options)
+	}
+
+	s.publishOutboundMessage(ctx, req.SenderJid, resp.ID, req.Type, req.To, msg)
+
+	return &proto.MessageResponse{
+		Id: resp.ID,
+	}, nil
+}
+
+func (s *service) ProcessSendReaction(ctx context.Context, req *proto.ReactionRequest) (*proto.MessageResponse, error) {
+
+	client, err := s.resolveSender(&req.SenderJid)
 	if err != nil {
-		s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish outbound message event: %v", err)
+		return nil, err
+	}
+
+	chat, err := types.ParseJID(req.ChatJid)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chat JID: %v", err)
+	}
+
+	targetSender := types.EmptyJID
+	if req.TargetSender != "" {
+		targetSender, err = types.ParseJID(req.TargetSender)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid target sender JID: %v", err)
+		}
+	}
+
+	msg := client.BuildReaction(chat, targetSender, req.MessageId, req.Emoji)
+	resp, err := client.SendMessage(ctx, chat, msg)
+	metrics.ObserveMessageSent(string(model.MessageTypeReaction), err)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to send reaction: %v", err)
 	}
 
+	s.publishOutboundMessage(ctx, req.SenderJid, resp.ID, string(model.MessageTypeReaction), req.ChatJid, msg)
+
 	return &proto.MessageResponse{
 		Id: resp.ID,
 	}, nil
 }
 
+// resolveSender finds the client of a sender device and normalizes senderJID to its registry key,
+// so everything downstream refers to the device the same way
+func (s *service) resolveSender(senderJID *string) (*whatsmeow.Client, error) {
+	key, client := s.clients.Resolve(*senderJID)
+	if client == nil {
+		return nil, status.Errorf(codes.NotFound, "sender device with JID %s not found", *senderJID)
+	}
+	*senderJID = key
+	return client, nil
+}
+
+// buildContextInfo builds the ContextInfo carrying the quoted message in req.ReplyTo and the
+// mentioned JIDs, or nil when the message is neither a reply nor mentions anyone
+func buildContextInfo(client *whatsmeow.Client, to types.JID, req *proto.MessagePayload) (*waProto.ContextInfo, error) {
+	mentions, err := resolveMentions(client, to, req)
+	if err != nil {
+		return nil, err
+	}
+
+	isReply := req.ReplyTo != nil && req.ReplyTo.MessageId != ""
+	if !isReply && len(mentions) == 0 {
+		return nil, nil
+	}
+
+	contextInfo := &waProto.ContextInfo{
+		MentionedJID: mentions,
+	}
+
+	if isReply {
+		// In a private chat the quoted message defaults to one of the recipient, in a group
+		// the sender of the quoted message cannot be guessed
+		participantJID := to
+		if req.ReplyTo.Participant != "" {
+			participantJID, err = types.ParseJID(req.ReplyTo.Participant)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid quoted participant JID: %v", err)
+			}
+		} else if to.Server == types.GroupServer {
+			return nil, status.Errorf(codes.InvalidArgument, "quoted participant is required when replying in a group")
+		}
+
+		contextInfo.StanzaID = protoStr(req.ReplyTo.MessageId)
+		contextInfo.Participant = protoStr(participantJID.String())
+		if req.ReplyTo.Text != "" {
+			contextInfo.QuotedMessage = &waProto.Message{
+				Conversation: protoStr(req.ReplyTo.Text),
+			}
+		}
+	}
+
+	return contextInfo, nil
+}
+
+// resolveMentions validates req.Mentions and expands "@all" to every participant of the target group
+func resolveMentions(client *whatsmeow.Client, to types.JID, req *proto.MessagePayload) ([]string, error) {
+	if len(req.Mentions) == 0 {
+		return nil, nil
+	}
+
+	isGroup := to.Server == types.GroupServer
+	var members map[string]bool
+
+	if isGroup && (req.CheckMentions || slices.Contains(req.Mentions, MentionAll)) {
+		group, err := client.GetGroupInfo(to)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get group info: %v", err)
+		}
+		members = make(map[string]bool)
+		for _, participant := range group.Participants {
+			members[participant.JID.ToNonAD().String()] = true
+			if !participant.PhoneNumber.IsEmpty() {
+				members[participant.PhoneNumber.ToNonAD().String()] = true
+			}
+		}
+		if slices.Contains(req.Mentions, MentionAll) {
+			mentions := make([]string, 0, len(group.Participants))
+			for _, participant := range group.Participants {
+				mentions = append(mentions, participant.JID.ToNonAD().String())
+			}
+			return mentions, nil
+		}
+	} else if slices.Contains(req.Mentions, MentionAll) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s mention is only allowed in group chats", MentionAll)
+	}
+
+	mentions := make([]string, 0, len(req.Mentions))
+	for _, mention := range req.Mentions {
+		mentionJID, err := parseMentionJID(mention)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid mentioned JID %s: %v", mention, err)
+		}
+		if members != nil && !members[mentionJID.String()] {
+			return nil, status.Errorf(codes.InvalidArgument, "mentioned JID %s is not a member of group %s", mentionJID.String(), to.String())
+		}
+		mentions = append(mentions, mentionJID.String())
+	}
+
+	return mentions, nil
+}
+
+// parseMentionJID parses a mentioned user, a bare phone number is normalised to a user JID
+func parseMentionJID(mention string) (types.JID, error) {
+	if !strings.Contains(mention, "@") {
+		number := cache.JIDUser(mention)
+		if number == "" || strings.IndexFunc(number, func(r rune) bool { return r < '0' || r > '9' }) != -1 {
+			return types.EmptyJID, errors.New("phone number must only contain digits")
+		}
+		return types.NewJID(number, types.DefaultUserServer), nil
+	}
+
+	jid, err := types.ParseJID(mention)
+	if err != nil {
+		return types.EmptyJID, err
+	}
+	if jid.User == "" || (jid.Server != types.DefaultUserServer && jid.Server != types.HiddenUserServer) {
+		return types.EmptyJID, fmt.Errorf("server must be %s or %s", types.DefaultUserServer, types.HiddenUserServer)
+	}
+	return jid.ToNonAD(), nil
+}
+
+// mimeTypeOr returns the caller supplied mime type, falling back to the sniffed one
+func mimeTypeOr(mimeType, detected string) string {
+	if mimeType != "" {
+		return mimeType
+	}
+	return detected
+}
+
+// publishOutboundMessage publishes an outbound message event to the messages queue
+func (s *service) publishOutboundMessage(ctx context.Context, senderJID, messageID, messageType, to string, msg *waProto.Message) {
+	eventBuilder := model.NewEventBuilder(senderJID)
+	queueEvent := eventBuilder.CreateOutboundMessageEvent(messageID, messageType, to, msg)
+	queueName := util.Configuration.Queues.MessagesEventQueue
+
+	err := s.publisher.Publish(ctx, queueName, queueEvent)
+	if err != nil {
+		s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish outbound message event: %v", err)
+	}
+}
+
+// buildContactMessage wraps a single contact into a ContactMessage carrying a vCard 3.0 payload.
+func buildContactMessage(contact *proto.Contact) (*waProto.ContactMessage, error) {
+	vcard, err := buildVCard(contact)
+	if err != nil {
+		return nil, err
+	}
+	return &waProto.ContactMessage{
+		DisplayName: protoStr(contact.Name),
+		Vcard:       protoStr(vcard),
+	}, nil
+}
+
+// buildVCard generates a vCard 3.0 string. The waid parameter lets WhatsApp link the card to an account.
+func buildVCard(contact *proto.Contact) (string, error) {
+	if contact == nil || strings.TrimSpace(contact.Name) == "" {
+		return "", status.Errorf(codes.InvalidArgument, "contact name cannot be empty")
+	}
+	waID := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, contact.Phone)
+	if waID == "" {
+		return "", status.Errorf(codes.InvalidArgument, "phone of contact %s must contain digits", contact.Name)
+	}
+
+	name := escapeVCard(contact.Name)
+	var b strings.Builder
+	b.WriteString("BEGIN:VCARD\n")
+	b.WriteString("VERSION:3.0\n")
+	fmt.Fprintf(&b, "N:;%s;;;\n", name)
+	fmt.Fprintf(&b, "FN:%s\n", name)
+	if contact.Organization != "" {
+		fmt.Fprintf(&b, "ORG:%s;\n", escapeVCard(contact.Organization))
+	}
+	fmt.Fprintf(&b, "TEL;type=CELL;type=VOICE;waid=%s:+%s\n", waID, waID)
+	if contact.Email != "" {
+		fmt.Fprintf(&b, "EMAIL;type=INTERNET:%s\n", escapeVCard(contact.Email))
+	}
+	b.WriteString("END:VCARD")
+	return b.String(), nil
+}
+
+// vCardEscaper escapes text values as required by RFC 2426 section 4, so a value cannot end its property
+var vCardEscaper = strings.NewReplacer(`\`, `\\`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`, ";", `\;`, ",", `\,`)
+
+func escapeVCard(value string) string {
+	return vCardEscaper.Replace(value)
+}
+
 func protoStr(s string) *string {
 	return &s
 }
@@ -205,3 +544,15 @@ func protoBool(b bool) *bool {
 func protoFloat(f float64) *float64 {
 	return &f
 }
+
+func protoFloat32(f float32) *float32 {
+	return &f
+}
+
+func protoUint32(u uint32) *uint32 {
+	return &u
+}
+
+func protoInt64(i int64) *int64 {
+	return &i
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"testing"
+
+	proto "wacoregateway/model/pb"
+
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBuildVCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		contact *proto.Contact
+		want    string
+	}{
+		{
+			name:    "name and phone",
+			contact: &proto.Contact{Name: "Budi", Phone: "+62 812-3456"},
+			want: "BEGIN:VCARD\nVERSION:3.0\nN:;Budi;;;\nFN:Budi\n" +
+				"TEL;type=CELL;type=VOICE;waid=628123456:+628123456\nEND:VCARD",
+		},
+		{
+			name:    "all fields",
+			contact: &proto.Contact{Name: "Budi", Phone: "628123456", Organization: "Acme", Email: "budi@example.com"},
+			want: "BEGIN:VCARD\nVERSION:3.0\nN:;Budi;;;\nFN:Budi\nORG:Acme;\n" +
+				"TEL;type=CELL;type=VOICE;waid=628123456:+628123456\nEMAIL;type=INTERNET:budi@example.com\nEND:VCARD",
+		},
+		{
+			name: "special characters are escaped",
+			contact: &proto.Contact{
+				Name:         "Budi; Jr, \\ II",
+				Phone:        "628123456",
+				Organization: "Acme\r\nEND:VCARD",
+				Email:        "budi@example.com\nTEL:+1",
+			},
+			want: "BEGIN:VCARD\nVERSION:3.0\nN:;Budi\\; Jr\\, \\\\ II;;;\nFN:Budi\\; Jr\\, \\\\ II\nORG:Acme\\nEND:VCARD;\n" +
+				"TEL;type=CELL;type=VOICE;waid=628123456:+628123456\nEMAIL;type=INTERNET:budi@example.com\\nTEL:+1\nEND:VCARD",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildVCard(tt.contact)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("buildVCard =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildVCardRejectsIncompleteContact(t *testing.T) {
+	for _, contact := range []*proto.Contact{
+		nil,
+		{Name: "", Phone: "628123456"},
+		{Name: "  ", Phone: "628123456"},
+		{Name: "Budi", Phone: ""},
+		{Name: "Budi", Phone: "+ -"},
+	} {
+		if _, err := buildVCard(contact); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("buildVCard(%v) code = %v, want %v", contact, status.Code(err), codes.InvalidArgument)
+		}
+	}
+}
+
+func TestBuildContextInfoReply(t *testing.T) {
+	private := types.NewJID("628123456", types.DefaultUserServer)
+	group := types.NewJID("120363000000000000", types.GroupServer)
+
+	tests := []struct {
+		name            string
+		to              types.JID
+		replyTo         *proto.ReplyTo
+		wantParticipant string
+		wantQuoted      bool
+		wantCode        codes.Code
+	}{
+		{"private chat defaults to recipient", private, &proto.ReplyTo{MessageId: "ABC", Text: "hi"}, private.String(), true, codes.OK},
+		{"explicit participant", private, &proto.ReplyTo{MessageId: "ABC", Participant: "628999@s.whatsapp.net"}, "628999@s.whatsapp.net", false, codes.OK},
+		{"group with participant", group, &proto.ReplyTo{MessageId: "ABC", Participant: "628999@s.whatsapp.net", Text: "hi"}, "628999@s.whatsapp.net", true, codes.OK},
+		{"group without participant", group, &proto.ReplyTo{MessageId: "ABC"}, "", false, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contextInfo, err := buildContextInfo(nil, tt.to, &proto.MessagePayload{ReplyTo: tt.replyTo})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got := contextInfo.GetParticipant(); got != tt.wantParticipant {
+				t.Errorf("participant = %q, want %q", got, tt.wantParticipant)
+			}
+			if got := contextInfo.GetQuotedMessage() != nil; got != tt.wantQuoted {
+				t.Errorf("quoted message set = %v, want %v", got, tt.wantQuoted)
+			}
+		})
+	}
+}
+
+func TestParseMentionJID(t *testing.T) {
+	tests := []struct {
+		mention string
+		want    string
+		ok      bool
+	}{
+		{"628123456", "628123456@s.whatsapp.net", true},
+		{"+628123456", "628123456@s.whatsapp.net", true},
+		{"628123456@s.whatsapp.net", "628123456@s.whatsapp.net", true},
+		{"628123456:12@s.whatsapp.net", "628123456@s.whatsapp.net", true},
+		{"123456789@lid", "123456789@lid", true},
+		{"", "", false},
+		{"budi", "", false},
+		{"120363000000000000@g.us", "", false},
+		{"@s.whatsapp.net", "", false},
+		{"status@broadcast", "", false},
+	}
+	for _, tt := range tests {
+		got, err := parseMentionJID(tt.mention)
+		if (err == nil) != tt.ok {
+			t.Errorf("parseMentionJID(%q) error = %v, want ok %v", tt.mention, err, tt.ok)
+			continue
+		}
+		if tt.ok && got.String() != tt.want {
+			t.Errorf("parseMentionJID(%q) = %s, want %s", tt.mention, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"wacoregateway/internal/metrics"
+	"wacoregateway/internal/provider"
+	"wacoregateway/model"
+	proto "wacoregateway/model/pb"
+	"wacoregateway/util"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Message types of revokes and edits in the sent messages metric
+const (
+	messageTypeRevoke = "revoke"
+	messageTypeEdit   = "edit"
+)
+
+// RevokeWindow is how long WhatsApp allows a message to be deleted for everyone after it was sent
+const RevokeWindow = 60 * time.Hour
+
+func (s *service) ProcessRevokeMessage(ctx context.Context, req *proto.RevokeMessageRequest) (*proto.MessageResponse, error) {
+
+	client, err := s.resolveSender(&req.SenderJid)
+	if err != nil {
+		return nil, err
+	}
+
+	chat, err := types.ParseJID(req.ChatJid)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chat JID: %v", err)
+	}
+
+	targetSender := types.EmptyJID
+	if req.TargetSender != "" {
+		targetSender, err = types.ParseJID(req.TargetSender)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid target sender JID: %v", err)
+		}
+	}
+
+	sentAt, err := s.resolveSentAt(req.SenderJid, chat, req.MessageId, req.SentAt)
+	if err != nil {
+		return nil, err
+	}
+	if time.Since(sentAt) > RevokeWindow {
+		return nil, status.Errorf(codes.FailedPrecondition, "message %s can no longer be revoked, revoke window of %s has expired", req.MessageId, RevokeWindow)
+	}
+
+	msg := client.BuildRevoke(chat, targetSender, req.MessageId)
+	resp, err := client.SendMessage(ctx, chat, msg)
+	metrics.ObserveMessageSent(messageTypeRevoke, err)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke message: %v", err)
+	}
+	s.sent.Delete(req.MessageId)
+
+	eventBuilder := model.NewEventBuilder(req.SenderJid)
+	queueEvent := eventBuilder.CreateMessageRevokedEvent(req.MessageId, chat.String(), req.SenderJid, targetSender.IsEmpty())
+	err = s.publisher.Publish(ctx, util.Configuration.Queues.MessagesEventQueue, queueEvent)
+	if err != nil {
+		s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish message revoked event: %v", err)
+	}
+
+	return &proto.MessageResponse{
+		Id: resp.ID,
+	}, nil
+}
+
+func (s *service) ProcessEditMessage(ctx context.Context, req *proto.EditMessageRequest) (*proto.MessageResponse, error) {
+
+	client, err := s.resolveSender(&req.SenderJid)
+	if err != nil {
+		return nil, err
+	}
+
+	chat, err := types.ParseJID(req.ChatJid)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chat JID: %v", err)
+	}
+
+	sentAt, err := s.resolveSentAt(req.SenderJid, chat, req.MessageId, req.SentAt)
+	if err != nil {
+		return nil, err
+	}
+	if time.Since(sentAt) > whatsmeow.EditWindow {
+		return nil, status.Errorf(codes.FailedPrecondition, "message %s can no longer be edited, edit window of %s has expired", req.MessageId, whatsmeow.EditWindow)
+	}
+
+	msg := client.BuildEdit(chat, req.MessageId, &waProto.Message{
+		Conversation: protoStr(req.Text),
+	})
+	resp, err := client.SendMessage(ctx, chat, msg)
+	metrics.ObserveMessageSent(messageTypeEdit, err)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to edit message: %v", err)
+	}
+
+	eventBuilder := model.NewEventBuilder(req.SenderJid)
+	queueEvent := eventBuilder.CreateMessageEditedEvent(req.MessageId, chat.String(), req.SenderJid, true, req.Text, msg)
+	err = s.publisher.Publish(ctx, util.Configuration.Queues.MessagesEventQueue, queueEvent)
+	if err != nil {
+		s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish message edited event: %v", err)
+	}
+
+	return &proto.MessageResponse{
+		Id: resp.ID,
+	}, nil
+}
+
+// resolveSentAt returns when a message was sent, preferring what the gateway recorded
+// over the caller supplied sentAt unix timestamp
+func (s *service) resolveSentAt(senderJID string, chat types.JID, messageID string, sentAt int64) (time.Time, error) {
+	if sent := s.sent.Get(messageID); sent != nil {
+		if sent.SenderJID != senderJID || sent.Chat != chat.String() {
+			return time.Time{}, status.Errorf(codes.PermissionDenied, "message %s was not sent by %s in chat %s", messageID, senderJID, chat.String())
+		}
+		return sent.SentAt, nil
+	}
+
+	if sentAt > 0 {
+		return time.Unix(sentAt, 0), nil
+	}
+
+	return time.Time{}, status.Errorf(codes.FailedPrecondition, "send time of message %s is unknown, sent_at param is required", messageID)
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"wacoregateway/internal/cache"
+	"wacoregateway/internal/metrics"
+	"wacoregateway/internal/provider"
+	"wacoregateway/internal/provider/messaging"
+	"wacoregateway/model"
+	"wacoregateway/model/constant"
+	"wacoregateway/util"
+
+	"go.mau.fi/whatsmeow"
+)
+
+// ReconnectSupervisor reconnects paired devices with exponential backoff and jitter
+// until they are connected again, logged out or out of attempts
+type ReconnectSupervisor struct {
+	clients        *cache.ClientRegistry
+	publisher      messaging.AMQPPublisherInterface
+	logger         provider.ILogger
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	maxAttempts    int
+	connect        func(client *whatsmeow.Client) error
+
+	mu      sync.Mutex
+	running map[*whatsmeow.Client]*reconnectRun
+	// attempts counts the attempts per client since it was last connected, across runs,
+	// since a connection that fails at login ends a run and starts the next one
+	attempts map[*whatsmeow.Client]int
+	stopped  bool
+}
+
+// reconnectRun identifies one reconnect loop, so a finished loop only forgets its own entry
+type reconnectRun struct {
+	cancel context.CancelFunc
+	// connecting is set while Connect runs, a failure reported meanwhile starts a new run
+	// since Connect can return before the login failed
+	connecting bool
+}
+
+func NewReconnectSupervisor(clients *cache.ClientRegistry, publisher messaging.AMQPPublisherInterface, logger provider.ILogger) *ReconnectSupervisor {
+	cfg := util.Configuration.Reconnect
+
+	initialBackoff := time.Duration(cfg.InitialBackoff) * time.Second
+	if initialBackoff <= 0 {
+		initialBackoff = 2 * time.Second
+	}
+	maxBackoff := time.Duration(cfg.MaxBackoff) * time.Second
+	if maxBackoff < initialBackoff {
+		maxBackoff = 5 * time.Minute
+	}
+
+	return &ReconnectSupervisor{
+		clients:        clients,
+		publisher:      publisher,
+		logger:         logger,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		maxAttempts:    cfg.MaxAttempts,
+		connect:        (*whatsmeow.Client).Connect,
+		running:        make(map[*whatsmeow.Client]*reconnectRun),
+		attempts:       make(map[*whatsmeow.Client]int),
+	}
+}
+
+// Supervise starts reconnecting client in the background, unless it is not paired or already being reconnected
+func (r *ReconnectSupervisor) Supervise(client *whatsmeow.Client, lastError string) {
+	if client.Store.ID == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return
+	}
+	if current, exists := r.running[client]; exists {
+		if !current.connecting {
+			return
+		}
+		current.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &reconnectRun{cancel: cancel}
+	r.running[client] = run
+
+	go func() {
+		defer r.done(client, run)
+		r.reconnect(ctx, client, run, lastError)
+	}()
+}
+
+// Stop cancels the reconnect attempts of client, if any
+func (r *ReconnectSupervisor) Stop(client *whatsmeow.Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, client)
+	if run, exists := r.running[client]; exists {
+		run.cancel()
+		delete(r.running, client)
+	}
+}
+
+// Reset forgets the attempts of client, it is called once the client is connected and logged in
+func (r *ReconnectSupervisor) Reset(client *whatsmeow.Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, client)
+}
+
+// StopAll cancels every running reconnect and ignores later calls to Supervise, it is used on shutdown
+func (r *ReconnectSupervisor) StopAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	for client, run := range r.running {
+		run.cancel()
+		delete(r.running, client)
+	}
+}
+
+// done forgets run, unless it was already replaced by a newer run of the same client
+func (r *ReconnectSupervisor) done(client *whatsmeow.Client, run *reconnectRun) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run.cancel()
+	if r.running[client] == run {
+		delete(r.running, client)
+	}
+}
+
+// nextAttempt counts a new attempt of run and returns its number, ok is false when run was replaced
+func (r *ReconnectSupervisor) nextAttempt(client *whatsmeow.Client, run *reconnectRun) (attempt int, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running[client] != run {
+		return 0, false
+	}
+	r.attempts[client]++
+	return r.attempts[client], true
+}
+
+// setConnecting marks whether run is connecting and reports whether it is still the current run of client
+func (r *ReconnectSupervisor) setConnecting(client *whatsmeow.Client, run *reconnectRun, connecting bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running[client] != run {
+		return false
+	}
+	run.connecting = connecting
+	return true
+}
+
+func (r *ReconnectSupervisor) reconnect(ctx context.Context, client *whatsmeow.Client, run *reconnectRun, lastError string) {
+	jid := client.Store.ID.String()
+	eventBuilder := model.NewEventBuilder(jid)
+	logCtx := context.WithValue(context.Background(), constant.CtxReqIDKey, jid)
+
+	for {
+		attempt, ok := r.nextAttempt(client, run)
+		if !ok {
+			return
+		}
+		if r.maxAttempts > 0 && attempt > r.maxAttempts {
+			break
+		}
+
+		nextRetryAt := time.Now().Add(r.backoff(attempt))
+		r.clients.UpdateStatus(jid, func(ds *cache.DeviceStatus) {
+			ds.State = cache.DeviceStateReconnecting
+			ds.ReconnectAttempts = attempt
+			ds.NextReconnectAt = nextRetryAt
+		})
+		r.logger.Infofctx(provider.AppLog, logCtx, "Reconnecting device %s, attempt %d at %s", jid, attempt, nextRetryAt.Format(time.RFC3339))
+		r.publish(logCtx, eventBuilder.CreateReconnectingEvent(attempt, r.maxAttempts, nextRetryAt, lastError))
+
+		timer := time.NewTimer(time.Until(nextRetryAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !r.setConnecting(client, run, true) {
+			return
+		}
+		err := r.connect(client)
+		if errors.Is(err, whatsmeow.ErrAlreadyConnected) {
+			err = nil
+		}
+		metrics.ObserveReconnectAttempt(err)
+		if err == nil {
+			// The Connected event resets the attempts, a failed login is supervised again by its event
+			// and continues counting
+			return
+		}
+		if !r.setConnecting(client, run, false) {
+			return
+		}
+		lastError = err.Error()
+		r.logger.Errorfctx(provider.AppLog, logCtx, false, "Failed to reconnect device %s: %v", jid, err)
+		r.clients.UpdateStatus(jid, func(ds *cache.DeviceStatus) {
+			ds.LastError = lastError
+		})
+	}
+
+	r.clients.UpdateStatus(jid, func(ds *cache.DeviceStatus) {
+		ds.State = cache.DeviceStateReconnectFailed
+		ds.NextReconnectAt = time.Time{}
+	})
+	r.logger.Errorfctx(provider.AppLog, logCtx, false, "Giving up reconnecting device %s after %d attempts", jid, r.maxAttempts)
+	r.publish(logCtx, eventBuilder.CreateReconnectFailedEvent(r.maxAttempts, lastError))
+}
+
+// backoff returns the delay before attempt, doubling from the initial backoff up to the max backoff
+// with the upper half randomized so devices do not reconnect in lockstep
+func (r *ReconnectSupervisor) backoff(attempt int) time.Duration {
+	delay := r.maxBackoff
+	if attempt < 32 {
+		if d := r.initialBackoff << (attempt - 1); d > 0 && d < r.maxBackoff {
+			delay = d
+		}
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func (r *ReconnectSupervisor) publish(ctx context.Context, queueEvent *model.QueueEvent) {
+	err := r.publisher.Publish(ctx, util.Configuration.Queues.EventHandlerQueue, queueEvent)
+	if err != nil {
+		r.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish reconnect event: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"wacoregateway/internal/cache"
+	"wacoregateway/internal/provider"
+	"wacoregateway/internal/provider/messaging"
+	"wacoregateway/model"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestReconnectRunOnlyForgetsItself(t *testing.T) {
+	r := &ReconnectSupervisor{running: make(map[*whatsmeow.Client]*reconnectRun)}
+	client := &whatsmeow.Client{}
+
+	oldCtx, oldCancel := context.WithCancel(context.Background())
+	old := &reconnectRun{cancel: oldCancel}
+	r.running[client] = old
+
+	// The old run is replaced, e.g. by Stop and Supervise, before its goroutine finished
+	r.Stop(client)
+	if oldCtx.Err() == nil {
+		t.Error("Stop did not cancel the run")
+	}
+	_, cancel := context.WithCancel(context.Background())
+	current := &reconnectRun{cancel: cancel}
+	r.running[client] = current
+
+	if r.setConnecting(client, old, true) {
+		t.Error("setConnecting of a replaced run = true, want false")
+	}
+	r.done(client, old)
+	if r.running[client] != current {
+		t.Fatal("done of a replaced run removed the current run")
+	}
+
+	if !r.setConnecting(client, current, true) || !current.connecting {
+		t.Error("setConnecting of the current run did not mark it connecting")
+	}
+	r.done(client, current)
+	if _, exists := r.running[client]; exists {
+		t.Error("done of the current run kept its entry")
+	}
+}
+
+// recordingPublisher keeps the published queue events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []*model.QueueEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, _ string, message any, _ ...messaging.AMQPPublisherOption) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, message.(*model.QueueEvent))
+	return nil
+}
+
+func (p *recordingPublisher) Flush(context.Context) error { return nil }
+
+func (p *recordingPublisher) eventTypes() []model.EventType {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	types := make([]model.EventType, 0, len(p.events))
+	for _, event := range p.events {
+		types = append(types, event.EventType)
+	}
+	return types
+}
+
+// discardLogger drops every log line
+type discardLogger struct{ provider.ILogger }
+
+func (discardLogger) Infofctx(provider.LogType, context.Context, string, ...interface{})        {}
+func (discardLogger) Errorfctx(provider.LogType, context.Context, bool, string, ...interface{}) {}
+
+func TestReconnectAttemptsSurviveFailedLogin(t *testing.T) {
+	jid := types.NewJID("6281111", types.DefaultUserServer)
+	client := &whatsmeow.Client{Store: &store.Device{ID: &jid}}
+	clients := cache.NewClientRegistry()
+	publisher := &recordingPublisher{}
+
+	// Connect succeeds every time, as it does when the websocket opens but the login fails afterwards
+	connected := make(chan time.Time, 1)
+	r := &ReconnectSupervisor{
+		clients:        clients,
+		publisher:      publisher,
+		logger:         discardLogger{},
+		initialBackoff: 20 * time.Millisecond,
+		maxBackoff:     time.Second,
+		maxAttempts:    3,
+		connect: func(*whatsmeow.Client) error {
+			connected <- time.Now()
+			return nil
+		},
+		running:  make(map[*whatsmeow.Client]*reconnectRun),
+		attempts: make(map[*whatsmeow.Client]int),
+	}
+
+	// The attempt n waits at least half of initialBackoff << (n-1)
+	for attempt := 1; attempt <= 3; attempt++ {
+		supervisedAt := time.Now()
+		r.Supervise(client, "connect failure")
+		select {
+		case at := <-connected:
+			if delay, want := at.Sub(supervisedAt), r.initialBackoff<<(attempt-1)/2; delay < want {
+				t.Errorf("attempt %d connected after %v, want at least %v", attempt, delay, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("attempt %d did not connect", attempt)
+		}
+		waitIdle(t, r, client)
+		if status := clients.Status(jid.String()); status.ReconnectAttempts != attempt {
+			t.Errorf("reconnect attempts = %d, want %d", status.ReconnectAttempts, attempt)
+		}
+	}
+
+	// Out of attempts, the next failure gives up without connecting
+	r.Supervise(client, "connect failure")
+	waitIdle(t, r, client)
+	select {
+	case <-connected:
+		t.Error("connected after max attempts")
+	default:
+	}
+	if got := publisher.eventTypes(); len(got) != 4 || got[3] != model.EventTypeReconnectFailed {
+		t.Errorf("published %v, want 3 reconnecting events and reconnect_failed", got)
+	}
+
+	// Connected starts counting again
+	r.Reset(client)
+	r.Supervise(client, "disconnected")
+	select {
+	case <-connected:
+	case <-time.After(time.Second):
+		t.Fatal("no attempt after Reset")
+	}
+	waitIdle(t, r, client)
+	if status := clients.Status(jid.String()); status.ReconnectAttempts != 1 {
+		t.Errorf("reconnect attempts after Reset = %d, want 1", status.ReconnectAttempts)
+	}
+}
+
+// waitIdle waits until the run of client ended
+func waitIdle(t *testing.T, r *ReconnectSupervisor, client *whatsmeow.Client) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		r.mu.Lock()
+		_, running := r.running[client]
+		r.mu.Unlock()
+		if !running {
+			return
+		}
+	}
+	t.Fatal("reconnect run did not end")
+}
@@ -3,32 +3,92 @@ package service
 import (
 	"context"
 
+	"wacoregateway/internal/cache"
 	"wacoregateway/internal/provider"
 	"wacoregateway/internal/provider/messaging"
 	proto "wacoregateway/model/pb"
 
 	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("wacoregateway/internal/service")
+
 type ServiceInterface interface {
 	LoadClients(ctx context.Context, container *sqlstore.Container) error
 	ProcessGetDevices(ctx context.Context) (*proto.DeviceListResponse, error)
 	ProcessGetContact(ctx context.Context, senderJID string) (*proto.ContactListResponse, error)
 	ProcessGetGroup(ctx context.Context, senderJID string) (*proto.GroupListResponse, error)
 	ProcessSendMessage(ctx context.Context, req *proto.MessagePayload) (*proto.MessageResponse, error)
+	ProcessSendReaction(ctx context.Context, req *proto.ReactionRequest) (*proto.MessageResponse, error)
+	ProcessRevokeMessage(ctx context.Context, req *proto.RevokeMessageRequest) (*proto.MessageResponse, error)
+	ProcessEditMessage(ctx context.Context, req *proto.EditMessageRequest) (*proto.MessageResponse, error)
 	ConnectDevice(ctx context.Context, container *sqlstore.Container, req *proto.ConnectDeviceRequest, stream proto.WaCoreGateway_StreamConnectDeviceServer) error
+	ProcessLogoutDevice(ctx context.Context, senderJID string) error
+	ProcessDeleteDevice(ctx context.Context, senderJID string) error
+	ProcessStreamLiveLocation(ctx context.Context, stream proto.WaCoreGateway_StreamLiveLocationServer) error
+	ProcessUploadMedia(ctx context.Context, stream proto.WaCoreGateway_UploadMediaServer) error
+	Drain(ctx context.Context) error
+	DisconnectAll(ctx context.Context)
 }
 
 type service struct {
 	container *sqlstore.Container
+	clients   *cache.ClientRegistry
+	reconnect *ReconnectSupervisor
 	logger    provider.ILogger
 	publisher messaging.AMQPPublisherInterface
+	media     *MediaDownloader
+	loader    *MediaLoader
+	polls     *cache.PollCache
+	shares    *cache.LiveLocationCache
+	sent      *cache.SentMessageCache
+	handles   *cache.MediaHandleCache
 }
 
-func NewService(container *sqlstore.Container, logger provider.ILogger, publisher messaging.AMQPPublisherInterface) ServiceInterface {
+func NewService(container *sqlstore.Container, clients *cache.ClientRegistry, logger provider.ILogger, publisher messaging.AMQPPublisherInterface, media *MediaDownloader, loader *MediaLoader, polls *cache.PollCache, shares *cache.LiveLocationCache, sent *cache.SentMessageCache, handles *cache.MediaHandleCache) ServiceInterface {
 	return &service{
 		container: container,
+		clients:   clients,
+		reconnect: NewReconnectSupervisor(clients, publisher, logger),
 		logger:    logger,
 		publisher: publisher,
+		media:     media,
+		loader:    loader,
+		polls:     polls,
+		shares:    shares,
+		sent:      sent,
+		handles:   handles,
+	}
+}
+
+// Drain waits for background media downloads, whose events are still published, and then for the event
+// publications in flight. It is called after DisconnectAll, new work is refused once draining started.
+func (s *service) Drain(ctx context.Context) error {
+	if err := s.media.Wait(ctx); err != nil {
+		return err
+	}
+	return s.publisher.Flush(ctx)
+}
+
+// DisconnectAll stops reconnecting and disconnects every client
+func (s *service) DisconnectAll(ctx context.Context) {
+	s.reconnect.StopAll()
+	for jid, client := range s.clients.Clients() {
+		if client.IsConnected() {
+			s.logger.Infofctx(provider.AppLog, ctx, "Disconnecting device %s", jid)
+			client.Disconnect()
+		}
+	}
+}
+
+// endSpan ends span, marking it failed when err is not nil
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
+	span.End()
 }
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// webpInfo describes the canvas of a WebP image
+type webpInfo struct {
+	Width    uint32
+	Height   uint32
+	Animated bool
+}
+
+// parseWebP validates the RIFF/WEBP header and reads the canvas size from the first chunk
+func parseWebP(data []byte) (*webpInfo, error) {
+	if len(data) < 30 || !bytes.Equal(data[0:4], []byte("RIFF")) || !bytes.Equal(data[8:12], []byte("WEBP")) {
+		return nil, errors.New("not a WebP file")
+	}
+
+	switch string(data[12:16]) {
+	case "VP8X":
+		// Extended format: flags byte followed by 24-bit canvas width-1 and height-1
+		return &webpInfo{
+			Animated: data[20]&0x02 != 0,
+			Width:    uint24(data[24:27]) + 1,
+			Height:   uint24(data[27:30]) + 1,
+		}, nil
+
+	case "VP8 ":
+		// Lossy format: 3 byte frame tag, start code, then 14-bit width and height
+		if !bytes.Equal(data[23:26], []byte{0x9d, 0x01, 0x2a}) {
+			return nil, errors.New("invalid VP8 frame header")
+		}
+		return &webpInfo{
+			Width:  uint32(binary.LittleEndian.Uint16(data[26:28]) & 0x3fff),
+			Height: uint32(binary.LittleEndian.Uint16(data[28:30]) & 0x3fff),
+		}, nil
+
+	case "VP8L":
+		// Lossless format: signature byte, then 14-bit width-1 and height-1
+		if data[20] != 0x2f {
+			return nil, errors.New("invalid VP8L signature")
+		}
+		bits := binary.LittleEndian.Uint32(data[21:25])
+		return &webpInfo{
+			Width:  bits&0x3fff + 1,
+			Height: (bits>>14)&0x3fff + 1,
+		}, nil
+	}
+
+	return nil, errors.New("unsupported WebP chunk")
+}
+
+func uint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
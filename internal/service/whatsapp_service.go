@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"wacoregateway/internal/cache"
 	"wacoregateway/internal/provider"
@@ -11,6 +12,7 @@ import (
 	"wacoregateway/util"
 
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/store/sqlstore"
 	"go.mau.fi/whatsmeow/types"
 	waLog "go.mau.fi/whatsmeow/util/log"
@@ -28,68 +30,233 @@ func (s *service) LoadClients(ctx context.Context, container *sqlstore.Container
 	}
 
 	for _, dev := range devices {
+		s.clients.UpdateStatus(dev.ID.String(), func(ds *cache.DeviceStatus) {
+			ds.State = cache.DeviceStateConnecting
+			ds.PushName = dev.PushName
+			ds.Platform = dev.Platform
+			ds.BusinessName = dev.BusinessName
+		})
+
 		client := whatsmeow.NewClient(dev, clientLog)
+		// Reconnecting is left to the reconnect supervisor
+		client.EnableAutoReconnect = false
+		AttachAllHandlers(s.clients, s.reconnect, dev.ID.String(), s.publisher, s.logger, s.media, s.polls, client, nil)
+		s.clients.Set(dev.ID.String(), client)
+
 		err := client.Connect()
 		if err != nil {
 			s.logger.Errorfctx(provider.AppLog, ctx, false, "failed to connect device %s: %v", dev.ID.String(), err)
-			continue
+			s.clients.UpdateStatus(dev.ID.String(), func(ds *cache.DeviceStatus) {
+				ds.State = cache.DeviceStateFailed
+				ds.LastError = err.Error()
+			})
+			s.reconnect.Supervise(client, err.Error())
 		}
-		AttachAllHandlers(dev.ID.String(), s.publisher, s.logger, client, nil)
-		cache.SetClient(dev.ID.String(), client)
 	}
 
 	return nil
 }
 
+const (
+	PairingModeQR   = "qr"
+	PairingModeCode = "code"
+
+	// PairClientDisplayName is shown in the linked devices list of the phone, it must be formatted as `Browser (OS)`
+	PairClientDisplayName = "Chrome (Linux)"
+)
+
 func (s *service) ConnectDevice(ctx context.Context, container *sqlstore.Container, req *proto.ConnectDeviceRequest, stream proto.WaCoreGateway_StreamConnectDeviceServer) error {
 	clientLog := waLog.Stdout("Client", "DEBUG", true)
 
+	codeMode := false
+	switch req.PairingMode {
+	case "", PairingModeQR:
+	case PairingModeCode:
+		if req.PhoneNumber == "" {
+			return status.Errorf(codes.InvalidArgument, "phone_number param cannot be empty for pairing mode %s", PairingModeCode)
+		}
+		codeMode = true
+	default:
+		return status.Errorf(codes.InvalidArgument, "unsupported pairing mode %s", req.PairingMode)
+	}
+
 	user := req.Name
 	jid := types.NewJID(user, types.DefaultUserServer)
 	device := container.NewDevice()
 
 	client := whatsmeow.NewClient(device, clientLog)
-	AttachAllHandlers(jid.String(), s.publisher, s.logger, client, stream)
-
-	cache.SetClient(jid.String(), client)
-
-	if client.Store.ID == nil {
-		eventBuilder := model.NewEventBuilder(jid.String())
-		qrChan, _ := client.GetQRChannel(context.Background())
-
-		go func() {
-			_ = client.Connect()
-		}()
-
-		for evt := range qrChan {
-			if evt.Event == whatsmeow.QRChannelEventCode {
-				// Emit to websocket
-				if err := stream.Send(&proto.EventResponse{
-					Type: "qr",
-					Qr:   evt.Code,
-				}); err != nil {
-					return err
-				}
+	client.EnableAutoReconnect = false
+	if !s.clients.Add(jid.String(), client) {
+		return status.Errorf(codes.AlreadyExists, "device with JID %s already exists", jid.String())
+	}
+	AttachAllHandlers(s.clients, s.reconnect, jid.String(), s.publisher, s.logger, s.media, s.polls, client, stream)
+
+	s.clients.UpdateStatus(jid.String(), func(ds *cache.DeviceStatus) {
+		ds.State = cache.DeviceStatePairing
+	})
+
+	// Once paired the client is keyed by its device JID, a pairing that ends otherwise leaves nothing behind
+	paired := false
+	defer func() {
+		if !paired {
+			s.clients.Remove(jid.String(), client)
+		}
+	}()
+
+	eventBuilder := model.NewEventBuilder(jid.String())
+	qrChan, _ := client.GetQRChannel(ctx)
 
-				// Publish to queue
-				queueEvent := eventBuilder.CreateQREvent(evt.Code)
-				err := s.publisher.Publish(ctx, util.Configuration.Queues.QRHandlerQueue, queueEvent, func(options *messaging.AMQPPublisherOptions) {})
-				if err != nil {
-					s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish QR event to queue: %v", err)
+	go func() {
+		_ = client.Connect()
+	}()
+
+	codeSent := false
+	for evt := range qrChan {
+		if evt == whatsmeow.QRChannelSuccess {
+			paired = true
+			continue
+		}
+		// In code mode the QR codes only signal that the websocket is ready, the linking code is requested once
+		if evt.Event == whatsmeow.QRChannelEventCode && codeMode {
+			if codeSent {
+				continue
+			}
+			code, err := client.PairPhone(ctx, req.PhoneNumber, true, whatsmeow.PairClientChrome, PairClientDisplayName)
+			if err != nil {
+				client.Disconnect()
+				if errors.Is(err, whatsmeow.ErrPhoneNumberTooShort) || errors.Is(err, whatsmeow.ErrPhoneNumberIsNotInternational) {
+					return status.Errorf(codes.InvalidArgument, "invalid phone number: %v", err)
 				}
+				return status.Errorf(codes.Internal, "failed to request pairing code: %v", err)
+			}
+			codeSent = true
+
+			if err := stream.Send(&proto.EventResponse{
+				Type: "pair_code",
+				Code: code,
+			}); err != nil {
+				return err
+			}
+
+			queueEvent := eventBuilder.CreatePairCodeEvent(code, req.PhoneNumber)
+			err = s.publisher.Publish(ctx, util.Configuration.Queues.QRHandlerQueue, queueEvent, func(options *messaging.AMQPPublisherOptions) {})
+			if err != nil {
+				s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish pair code event to queue: %v", err)
+			}
+			continue
+		}
+
+		if evt.Event == whatsmeow.QRChannelEventCode {
+			// Emit to websocket
+			if err := stream.Send(&proto.EventResponse{
+				Type: "qr",
+				Qr:   evt.Code,
+			}); err != nil {
+				return err
+			}
+
+			// Publish to queue
+			queueEvent := eventBuilder.CreateQREvent(evt.Code)
+			err := s.publisher.Publish(ctx, util.Configuration.Queues.QRHandlerQueue, queueEvent, func(options *messaging.AMQPPublisherOptions) {})
+			if err != nil {
+				s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish QR event to queue: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ProcessLogoutDevice unlinks the device from the phone, which also removes it from the device store
+func (s *service) ProcessLogoutDevice(ctx context.Context, senderJID string) error {
+	client, err := s.resolveSender(&senderJID)
+	if err != nil {
+		return err
+	}
+
+	s.reconnect.Stop(client)
+	if err = client.Logout(ctx); err != nil {
+		if errors.Is(err, whatsmeow.ErrNotLoggedIn) {
+			return status.Errorf(codes.FailedPrecondition, "client with JID %s is not logged in", senderJID)
+		}
+		return status.Errorf(codes.Internal, "failed to logout device: %v", err)
+	}
+	s.clients.Delete(senderJID)
+
+	s.publishLoggedOut(ctx, senderJID)
+	return nil
+}
+
+// ProcessDeleteDevice removes the device even when it is not connected or the logout request fails
+func (s *service) ProcessDeleteDevice(ctx context.Context, senderJID string) error {
+	var device *store.Device
+
+	if client, err := s.resolveSender(&senderJID); err == nil {
+		s.reconnect.Stop(client)
+		if client.IsLoggedIn() {
+			if err := client.Logout(ctx); err != nil {
+				s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to logout device %s before deleting it: %v", senderJID, err)
 			}
 		}
+		device = client.Store
+		s.clients.Delete(senderJID)
 	} else {
-		return status.Errorf(codes.AlreadyExists, "device with JID %s already exists", jid.String())
+		var err error
+		device, err = s.findStoredDevice(ctx, senderJID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get device: %v", err)
+		}
+		if device == nil {
+			return status.Errorf(codes.NotFound, "device with JID %s not found", senderJID)
+		}
+		senderJID = device.ID.String()
+	}
+
+	// A successful logout already deleted the device from the store
+	if device.ID != nil {
+		if err := device.Delete(ctx); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete device: %v", err)
+		}
 	}
+	s.clients.Delete(senderJID)
 
+	s.publishLoggedOut(ctx, senderJID)
 	return nil
 }
 
+// findStoredDevice finds a device in the store by full device JID, bare JID or phone number
+func (s *service) findStoredDevice(ctx context.Context, id string) (*store.Device, error) {
+	devices, err := s.container.GetAllDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user := cache.JIDUser(id)
+	var found *store.Device
+	for _, dev := range devices {
+		if dev.ID.String() == id {
+			return dev, nil
+		}
+		if found == nil && user != "" && dev.ID.User == user {
+			found = dev
+		}
+	}
+	return found, nil
+}
+
+func (s *service) publishLoggedOut(ctx context.Context, senderJID string) {
+	eventBuilder := model.NewEventBuilder(senderJID)
+	queueEvent := eventBuilder.CreateLoggedOutEvent()
+	err := s.publisher.Publish(ctx, util.Configuration.Queues.EventHandlerQueue, queueEvent)
+	if err != nil {
+		s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to publish logged out event: %v", err)
+	}
+}
+
 func (s *service) ProcessGetContact(ctx context.Context, senderJID string) (*proto.ContactListResponse, error) {
-	client := cache.GetClient(senderJID)
-	if client == nil {
-		return nil, status.Errorf(codes.NotFound, "client with JID %s not found", senderJID)
+	client, err := s.resolveSender(&senderJID)
+	if err != nil {
+		return nil, err
 	}
 
 	contacts, err := client.Store.Contacts.GetAllContacts(ctx)
@@ -110,9 +277,9 @@ func (s *service) ProcessGetContact(ctx context.Context, senderJID string) (*pro
 }
 
 func (s *service) ProcessGetGroup(ctx context.Context, senderJID string) (*proto.GroupListResponse, error) {
-	client := cache.GetClient(senderJID)
-	if client == nil {
-		return nil, status.Errorf(codes.NotFound, "client with JID %s not found", senderJID)
+	client, err := s.resolveSender(&senderJID)
+	if err != nil {
+		return nil, err
 	}
 
 	groups, err := client.GetJoinedGroups()
@@ -133,16 +300,58 @@ func (s *service) ProcessGetGroup(ctx context.Context, senderJID string) (*proto
 
 func (s *service) ProcessGetDevices(ctx context.Context) (*proto.DeviceListResponse, error) {
 
-	clients := cache.GetAllClients()
-	if clients == nil {
-		return nil, status.Errorf(codes.NotFound, "no clients found")
-	}
+	clients := s.clients.Clients()
 	result := &proto.DeviceListResponse{}
-	for jid := range clients {
-		result.Devices = append(result.Devices, &proto.DeviceItem{
-			Jid: jid,
-		})
+	for jid, client := range clients {
+		item := deviceItem(jid, s.clients.Status(jid))
+		item.LoggedIn = client.IsLoggedIn()
+		result.Devices = append(result.Devices, item)
+	}
+
+	// Devices that failed to connect on startup are in the store but not in the clients cache
+	devices, err := s.container.GetAllDevices(ctx)
+	if err != nil {
+		s.logger.Errorfctx(provider.AppLog, ctx, false, "Failed to get device store: %v", err)
+		return result, nil
+	}
+	for _, dev := range devices {
+		jid := dev.ID.String()
+		if _, exists := clients[jid]; exists {
+			continue
+		}
+		item := deviceItem(jid, s.clients.Status(jid))
+		if item.State == "" {
+			item.State = cache.DeviceStateDisconnected
+			item.PushName = dev.PushName
+			item.Platform = dev.Platform
+			item.BusinessName = dev.BusinessName
+		}
+		result.Devices = append(result.Devices, item)
 	}
 
 	return result, nil
 }
+
+// deviceItem converts the tracked status of a device, which may be nil, to a DeviceItem
+func deviceItem(jid string, ds *cache.DeviceStatus) *proto.DeviceItem {
+	item := &proto.DeviceItem{Jid: jid}
+	if ds == nil {
+		return item
+	}
+	item.State = ds.State
+	item.PushName = ds.PushName
+	item.Platform = ds.Platform
+	item.BusinessName = ds.BusinessName
+	item.LastError = ds.LastError
+	item.ReconnectAttempts = int32(ds.ReconnectAttempts)
+	if !ds.NextReconnectAt.IsZero() {
+		item.NextReconnectAt = ds.NextReconnectAt.Unix()
+	}
+	if !ds.LastConnectedAt.IsZero() {
+		item.LastConnectedAt = ds.LastConnectedAt.Unix()
+	}
+	if !ds.LastDisconnectedAt.IsZero() {
+		item.LastDisconnectedAt = ds.LastDisconnectedAt.Unix()
+	}
+	return item
+}
@@ -1,5 +1,6 @@
 package constant
 
+// RequestIDKey holds the request ID of the RPC a context belongs to, it is published as correlation ID
 type RequestIDKey struct{}
 type Username struct{}
 
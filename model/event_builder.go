@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	waProto "go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -60,6 +61,37 @@ func (eb *EventBuilder) CreateLoggedOutEvent() *QueueEvent {
 	}
 }
 
+// CreateReconnectingEvent creates a queue event for a scheduled reconnect attempt
+func (eb *EventBuilder) CreateReconnectingEvent(attempt, maxAttempts int, nextRetryAt time.Time, lastError string) *QueueEvent {
+	return &QueueEvent{
+		EventID:   uuid.New().String(),
+		SenderJID: eb.SenderJID,
+		EventType: EventTypeReconnecting,
+		Timestamp: time.Now(),
+		Data: ReconnectEventData{
+			Attempt:     attempt,
+			MaxAttempts: maxAttempts,
+			NextRetryAt: nextRetryAt.Unix(),
+			Error:       lastError,
+		},
+	}
+}
+
+// CreateReconnectFailedEvent creates a queue event for a device that could not be reconnected
+func (eb *EventBuilder) CreateReconnectFailedEvent(attempts int, lastError string) *QueueEvent {
+	return &QueueEvent{
+		EventID:   uuid.New().String(),
+		SenderJID: eb.SenderJID,
+		EventType: EventTypeReconnectFailed,
+		Timestamp: time.Now(),
+		Data: ReconnectEventData{
+			Attempt:     attempts,
+			MaxAttempts: attempts,
+			Error:       lastError,
+		},
+	}
+}
+
 // CreateQREvent creates a queue event for QR events
 func (eb *EventBuilder) CreateQREvent(code string) *QueueEvent {
 	return &QueueEvent{
@@ -73,6 +105,20 @@ func (eb *EventBuilder) CreateQREvent(code string) *QueueEvent {
 	}
 }
 
+// CreatePairCodeEvent creates a queue event for phone number pairing code events
+func (eb *EventBuilder) CreatePairCodeEvent(code, phoneNumber string) *QueueEvent {
+	return &QueueEvent{
+		EventID:   uuid.New().String(),
+		SenderJID: eb.SenderJID,
+		EventType: EventTypePairCode,
+		Timestamp: time.Now(),
+		Data: PairCodeEventData{
+			Code:        code,
+			PhoneNumber: phoneNumber,
+		},
+	}
+}
+
 // CreateTextMessageEvent creates a queue event for text message events
 func (eb *EventBuilder) CreateTextMessageEvent(sender, content string) *QueueEvent {
 	return &QueueEvent{
@@ -331,11 +377,17 @@ func (eb *EventBuilder) CreatePairSuccessEvent(senderJID, PhoneNumber string, de
 	}
 }
 
-// CreateGenericMessageEvent creates a generic message event from events.Message
-func (eb *EventBuilder) CreateGenericMessageEvent(evt *events.Message) *QueueEvent {
+// CreateGenericMessageEvent creates a generic message event from events.Message.
+// media is attached to media message data when the gateway downloaded it, and may be nil
+func (eb *EventBuilder) CreateGenericMessageEvent(evt *events.Message, media *StoredMedia) *QueueEvent {
 	sender := evt.Info.Sender.String()
 	msg := evt.Message
 
+	stored := StoredMedia{}
+	if media != nil {
+		stored = *media
+	}
+
 	var messageData interface{}
 
 	switch {
@@ -365,10 +417,11 @@ func (eb *EventBuilder) CreateGenericMessageEvent(evt *events.Message) *QueueEve
 					"chat":       evt.Info.Chat.String(),
 				},
 			},
-			Caption:  imgMsg.GetCaption(),
-			MimeType: imgMsg.GetMimetype(),
-			FileSize: imgMsg.GetFileLength(),
-			FileURL:  imgMsg.GetURL(),
+			StoredMedia: stored,
+			Caption:     imgMsg.GetCaption(),
+			MimeType:    imgMsg.GetMimetype(),
+			FileSize:    imgMsg.GetFileLength(),
+			FileURL:     imgMsg.GetURL(),
 		}
 
 	case msg.GetAudioMessage() != nil:
@@ -384,10 +437,11 @@ func (eb *EventBuilder) CreateGenericMessageEvent(evt *events.Message) *QueueEve
 					"chat":       evt.Info.Chat.String(),
 				},
 			},
-			Duration: audioMsg.GetSeconds(),
-			MimeType: audioMsg.GetMimetype(),
-			FileSize: audioMsg.GetFileLength(),
-			FileURL:  audioMsg.GetURL(),
+			StoredMedia: stored,
+			Duration:    audioMsg.GetSeconds(),
+			MimeType:    audioMsg.GetMimetype(),
+			FileSize:    audioMsg.GetFileLength(),
+			FileURL:     audioMsg.GetURL(),
 		}
 
 	case msg.GetVideoMessage() != nil:
@@ -403,11 +457,12 @@ func (eb *EventBuilder) CreateGenericMessageEvent(evt *events.Message) *QueueEve
 					"chat":       evt.Info.Chat.String(),
 				},
 			},
-			Caption:  videoMsg.GetCaption(),
-			Duration: videoMsg.GetSeconds(),
-			MimeType: videoMsg.GetMimetype(),
-			FileSize: videoMsg.GetFileLength(),
-			FileURL:  videoMsg.GetURL(),
+			StoredMedia: stored,
+			Caption:     videoMsg.GetCaption(),
+			Duration:    videoMsg.GetSeconds(),
+			MimeType:    videoMsg.GetMimetype(),
+			FileSize:    videoMsg.GetFileLength(),
+			FileURL:     videoMsg.GetURL(),
 		}
 
 	case msg.GetDocumentMessage() != nil:
@@ -423,10 +478,33 @@ func (eb *EventBuilder) CreateGenericMessageEvent(evt *events.Message) *QueueEve
 					"chat":       evt.Info.Chat.String(),
 				},
 			},
-			FileName: docMsg.GetFileName(),
-			MimeType: docMsg.GetMimetype(),
-			FileSize: docMsg.GetFileLength(),
-			FileURL:  docMsg.GetURL(),
+			StoredMedia: stored,
+			FileName:    docMsg.GetFileName(),
+			MimeType:    docMsg.GetMimetype(),
+			FileSize:    docMsg.GetFileLength(),
+			FileURL:     docMsg.GetURL(),
+		}
+
+	case msg.GetStickerMessage() != nil:
+		stickerMsg := msg.GetStickerMessage()
+		messageData = StickerMessageData{
+			MessageEventData: MessageEventData{
+				Sender:      sender,
+				MessageType: MessageTypeSticker,
+				Metadata: map[string]interface{}{
+					"message_id": evt.Info.ID,
+					"timestamp":  evt.Info.Timestamp.Unix(),
+					"from_me":    evt.Info.IsFromMe,
+					"chat":       evt.Info.Chat.String(),
+				},
+			},
+			StoredMedia: stored,
+			MimeType:    stickerMsg.GetMimetype(),
+			IsAnimated:  stickerMsg.GetIsAnimated(),
+			FileSize:    stickerMsg.GetFileLength(),
+			Width:       stickerMsg.GetWidth(),
+			Height:      stickerMsg.GetHeight(),
+			FileURL:     stickerMsg.GetURL(),
 		}
 
 	case msg.GetLocationMessage() != nil:
@@ -500,6 +578,28 @@ func (eb *EventBuilder) CreateGenericMessageEvent(evt *events.Message) *QueueEve
 			SelectedRowID: listMsg.GetSingleSelectReply().GetSelectedRowID(),
 		}
 
+	case GetPollCreationMessage(msg) != nil:
+		pollMsg := GetPollCreationMessage(msg)
+		options := make([]string, 0, len(pollMsg.GetOptions()))
+		for _, option := range pollMsg.GetOptions() {
+			options = append(options, option.GetOptionName())
+		}
+		messageData = PollMessageData{
+			MessageEventData: MessageEventData{
+				Sender:      sender,
+				MessageType: MessageTypePoll,
+				Metadata: map[string]interface{}{
+					"message_id": evt.Info.ID,
+					"timestamp":  evt.Info.Timestamp.Unix(),
+					"from_me":    evt.Info.IsFromMe,
+					"chat":       evt.Info.Chat.String(),
+				},
+			},
+			Question:        pollMsg.GetName(),
+			Options:         options,
+			SelectableCount: pollMsg.GetSelectableOptionsCount(),
+		}
+
 	default:
 		messageData = MessageEventData{
 			Sender:      sender,
@@ -538,3 +638,68 @@ func (eb *EventBuilder) CreateOutboundMessageEvent(messageID, messageType, to st
 		},
 	}
 }
+
+// CreateMessageRevokedEvent creates a queue event for message revoke events
+func (eb *EventBuilder) CreateMessageRevokedEvent(messageID, chat, revokedBy string, fromMe bool) *QueueEvent {
+	return &QueueEvent{
+		EventID:   uuid.New().String(),
+		SenderJID: eb.SenderJID,
+		EventType: EventTypeMessageRevoked,
+		Timestamp: time.Now(),
+		Data: MessageRevokedEventData{
+			MessageID: messageID,
+			Chat:      chat,
+			RevokedBy: revokedBy,
+			FromMe:    fromMe,
+		},
+	}
+}
+
+// CreateMessageEditedEvent creates a queue event for message edit events
+func (eb *EventBuilder) CreateMessageEditedEvent(messageID, chat, editedBy string, fromMe bool, text string, message interface{}) *QueueEvent {
+	return &QueueEvent{
+		EventID:   uuid.New().String(),
+		SenderJID: eb.SenderJID,
+		EventType: EventTypeMessageEdited,
+		Timestamp: time.Now(),
+		Data: MessageEditedEventData{
+			MessageID: messageID,
+			Chat:      chat,
+			EditedBy:  editedBy,
+			FromMe:    fromMe,
+			Text:      text,
+			Message:   message,
+		},
+	}
+}
+
+// CreatePollVoteEvent creates a queue event for decrypted poll vote events
+func (eb *EventBuilder) CreatePollVoteEvent(pollID, chat, voter string, selectedOptions, unknownHashes []string, timestamp int64) *QueueEvent {
+	return &QueueEvent{
+		EventID:   uuid.New().String(),
+		SenderJID: eb.SenderJID,
+		EventType: EventTypePollVote,
+		Timestamp: time.Now(),
+		Data: PollVoteEventData{
+			PollID:          pollID,
+			Chat:            chat,
+			Voter:           voter,
+			SelectedOptions: selectedOptions,
+			UnknownHashes:   unknownHashes,
+			Timestamp:       timestamp,
+		},
+	}
+}
+
+// GetPollCreationMessage returns the poll creation message of any version, or nil if msg is not a poll
+func GetPollCreationMessage(msg *waProto.Message) *waProto.PollCreationMessage {
+	switch {
+	case msg.GetPollCreationMessage() != nil:
+		return msg.GetPollCreationMessage()
+	case msg.GetPollCreationMessageV2() != nil:
+		return msg.GetPollCreationMessageV2()
+	case msg.GetPollCreationMessageV3() != nil:
+		return msg.GetPollCreationMessageV3()
+	}
+	return nil
+}
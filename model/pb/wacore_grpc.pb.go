@@ -25,6 +25,13 @@ const (
 	WaCoreGateway_GetAllDevice_FullMethodName        = "/wacoreproto.WaCoreGateway/GetAllDevice"
 	WaCoreGateway_SendMessage_FullMethodName         = "/wacoreproto.WaCoreGateway/SendMessage"
 	WaCoreGateway_StreamConnectDevice_FullMethodName = "/wacoreproto.WaCoreGateway/StreamConnectDevice"
+	WaCoreGateway_StreamLiveLocation_FullMethodName  = "/wacoreproto.WaCoreGateway/StreamLiveLocation"
+	WaCoreGateway_SendReaction_FullMethodName        = "/wacoreproto.WaCoreGateway/SendReaction"
+	WaCoreGateway_RevokeMessage_FullMethodName       = "/wacoreproto.WaCoreGateway/RevokeMessage"
+	WaCoreGateway_EditMessage_FullMethodName         = "/wacoreproto.WaCoreGateway/EditMessage"
+	WaCoreGateway_UploadMedia_FullMethodName         = "/wacoreproto.WaCoreGateway/UploadMedia"
+	WaCoreGateway_LogoutDevice_FullMethodName        = "/wacoreproto.WaCoreGateway/LogoutDevice"
+	WaCoreGateway_DeleteDevice_FullMethodName        = "/wacoreproto.WaCoreGateway/DeleteDevice"
 )
 
 // WaCoreGatewayClient is the client API for WaCoreGateway service.
@@ -36,6 +43,13 @@ type WaCoreGatewayClient interface {
 	GetAllDevice(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeviceListResponse, error)
 	SendMessage(ctx context.Context, in *MessagePayload, opts ...grpc.CallOption) (*MessageResponse, error)
 	StreamConnectDevice(ctx context.Context, in *ConnectDeviceRequest, opts ...grpc.CallOption) (WaCoreGateway_StreamConnectDeviceClient, error)
+	StreamLiveLocation(ctx context.Context, opts ...grpc.CallOption) (WaCoreGateway_StreamLiveLocationClient, error)
+	SendReaction(ctx context.Context, in *ReactionRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	RevokeMessage(ctx context.Context, in *RevokeMessageRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*MessageResponse, error)
+	UploadMedia(ctx context.Context, opts ...grpc.CallOption) (WaCoreGateway_UploadMediaClient, error)
+	LogoutDevice(ctx context.Context, in *ClientdataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteDevice(ctx context.Context, in *ClientdataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type waCoreGatewayClient struct {
@@ -114,6 +128,119 @@ func (x *waCoreGatewayStreamConnectDeviceClient) Recv() (*EventResponse, error)
 	return m, nil
 }
 
+func (c *waCoreGatewayClient) StreamLiveLocation(ctx context.Context, opts ...grpc.CallOption) (WaCoreGateway_StreamLiveLocationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WaCoreGateway_ServiceDesc.Streams[1], WaCoreGateway_StreamLiveLocation_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &waCoreGatewayStreamLiveLocationClient{stream}
+	return x, nil
+}
+
+type WaCoreGateway_StreamLiveLocationClient interface {
+	Send(*LiveLocationUpdate) error
+	CloseAndRecv() (*LiveLocationResponse, error)
+	grpc.ClientStream
+}
+
+type waCoreGatewayStreamLiveLocationClient struct {
+	grpc.ClientStream
+}
+
+func (x *waCoreGatewayStreamLiveLocationClient) Send(m *LiveLocationUpdate) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *waCoreGatewayStreamLiveLocationClient) CloseAndRecv() (*LiveLocationResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(LiveLocationResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *waCoreGatewayClient) SendReaction(ctx context.Context, in *ReactionRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, WaCoreGateway_SendReaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *waCoreGatewayClient) RevokeMessage(ctx context.Context, in *RevokeMessageRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, WaCoreGateway_RevokeMessage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *waCoreGatewayClient) EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*MessageResponse, error) {
+	out := new(MessageResponse)
+	err := c.cc.Invoke(ctx, WaCoreGateway_EditMessage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *waCoreGatewayClient) UploadMedia(ctx context.Context, opts ...grpc.CallOption) (WaCoreGateway_UploadMediaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WaCoreGateway_ServiceDesc.Streams[2], WaCoreGateway_UploadMedia_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &waCoreGatewayUploadMediaClient{stream}
+	return x, nil
+}
+
+type WaCoreGateway_UploadMediaClient interface {
+	Send(*UploadMediaRequest) error
+	CloseAndRecv() (*UploadMediaResponse, error)
+	grpc.ClientStream
+}
+
+type waCoreGatewayUploadMediaClient struct {
+	grpc.ClientStream
+}
+
+func (x *waCoreGatewayUploadMediaClient) Send(m *UploadMediaRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *waCoreGatewayUploadMediaClient) CloseAndRecv() (*UploadMediaResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadMediaResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *waCoreGatewayClient) LogoutDevice(ctx context.Context, in *ClientdataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WaCoreGateway_LogoutDevice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *waCoreGatewayClient) DeleteDevice(ctx context.Context, in *ClientdataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WaCoreGateway_DeleteDevice_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WaCoreGatewayServer is the server API for WaCoreGateway service.
 // All implementations must embed UnimplementedWaCoreGatewayServer
 // for forward compatibility
@@ -123,6 +250,13 @@ type WaCoreGatewayServer interface {
 	GetAllDevice(context.Context, *emptypb.Empty) (*DeviceListResponse, error)
 	SendMessage(context.Context, *MessagePayload) (*MessageResponse, error)
 	StreamConnectDevice(*ConnectDeviceRequest, WaCoreGateway_StreamConnectDeviceServer) error
+	StreamLiveLocation(WaCoreGateway_StreamLiveLocationServer) error
+	SendReaction(context.Context, *ReactionRequest) (*MessageResponse, error)
+	RevokeMessage(context.Context, *RevokeMessageRequest) (*MessageResponse, error)
+	EditMessage(context.Context, *EditMessageRequest) (*MessageResponse, error)
+	UploadMedia(WaCoreGateway_UploadMediaServer) error
+	LogoutDevice(context.Context, *ClientdataRequest) (*emptypb.Empty, error)
+	DeleteDevice(context.Context, *ClientdataRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedWaCoreGatewayServer()
 }
 
@@ -145,6 +279,27 @@ func (UnimplementedWaCoreGatewayServer) SendMessage(context.Context, *MessagePay
 func (UnimplementedWaCoreGatewayServer) StreamConnectDevice(*ConnectDeviceRequest, WaCoreGateway_StreamConnectDeviceServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamConnectDevice not implemented")
 }
+func (UnimplementedWaCoreGatewayServer) StreamLiveLocation(WaCoreGateway_StreamLiveLocationServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLiveLocation not implemented")
+}
+func (UnimplementedWaCoreGatewayServer) SendReaction(context.Context, *ReactionRequest) (*MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendReaction not implemented")
+}
+func (UnimplementedWaCoreGatewayServer) RevokeMessage(context.Context, *RevokeMessageRequest) (*MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeMessage not implemented")
+}
+func (UnimplementedWaCoreGatewayServer) EditMessage(context.Context, *EditMessageRequest) (*MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditMessage not implemented")
+}
+func (UnimplementedWaCoreGatewayServer) UploadMedia(WaCoreGateway_UploadMediaServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadMedia not implemented")
+}
+func (UnimplementedWaCoreGatewayServer) LogoutDevice(context.Context, *ClientdataRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutDevice not implemented")
+}
+func (UnimplementedWaCoreGatewayServer) DeleteDevice(context.Context, *ClientdataRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevice not implemented")
+}
 func (UnimplementedWaCoreGatewayServer) mustEmbedUnimplementedWaCoreGatewayServer() {}
 
 // UnsafeWaCoreGatewayServer may be embedded to opt out of forward compatibility for this service.
@@ -251,6 +406,148 @@ func (x *waCoreGatewayStreamConnectDeviceServer) Send(m *EventResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _WaCoreGateway_StreamLiveLocation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WaCoreGatewayServer).StreamLiveLocation(&waCoreGatewayStreamLiveLocationServer{stream})
+}
+
+type WaCoreGateway_StreamLiveLocationServer interface {
+	SendAndClose(*LiveLocationResponse) error
+	Recv() (*LiveLocationUpdate, error)
+	grpc.ServerStream
+}
+
+type waCoreGatewayStreamLiveLocationServer struct {
+	grpc.ServerStream
+}
+
+func (x *waCoreGatewayStreamLiveLocationServer) SendAndClose(m *LiveLocationResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *waCoreGatewayStreamLiveLocationServer) Recv() (*LiveLocationUpdate, error) {
+	m := new(LiveLocationUpdate)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _WaCoreGateway_SendReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WaCoreGatewayServer).SendReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WaCoreGateway_SendReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WaCoreGatewayServer).SendReaction(ctx, req.(*ReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WaCoreGateway_RevokeMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WaCoreGatewayServer).RevokeMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WaCoreGateway_RevokeMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WaCoreGatewayServer).RevokeMessage(ctx, req.(*RevokeMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WaCoreGateway_EditMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WaCoreGatewayServer).EditMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WaCoreGateway_EditMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WaCoreGatewayServer).EditMessage(ctx, req.(*EditMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WaCoreGateway_UploadMedia_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WaCoreGatewayServer).UploadMedia(&waCoreGatewayUploadMediaServer{stream})
+}
+
+type WaCoreGateway_UploadMediaServer interface {
+	SendAndClose(*UploadMediaResponse) error
+	Recv() (*UploadMediaRequest, error)
+	grpc.ServerStream
+}
+
+type waCoreGatewayUploadMediaServer struct {
+	grpc.ServerStream
+}
+
+func (x *waCoreGatewayUploadMediaServer) SendAndClose(m *UploadMediaResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *waCoreGatewayUploadMediaServer) Recv() (*UploadMediaRequest, error) {
+	m := new(UploadMediaRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _WaCoreGateway_LogoutDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientdataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WaCoreGatewayServer).LogoutDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WaCoreGateway_LogoutDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WaCoreGatewayServer).LogoutDevice(ctx, req.(*ClientdataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WaCoreGateway_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientdataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WaCoreGatewayServer).DeleteDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WaCoreGateway_DeleteDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WaCoreGatewayServer).DeleteDevice(ctx, req.(*ClientdataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WaCoreGateway_ServiceDesc is the grpc.ServiceDesc for WaCoreGateway service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -274,6 +571,26 @@ var WaCoreGateway_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendMessage",
 			Handler:    _WaCoreGateway_SendMessage_Handler,
 		},
+		{
+			MethodName: "SendReaction",
+			Handler:    _WaCoreGateway_SendReaction_Handler,
+		},
+		{
+			MethodName: "RevokeMessage",
+			Handler:    _WaCoreGateway_RevokeMessage_Handler,
+		},
+		{
+			MethodName: "EditMessage",
+			Handler:    _WaCoreGateway_EditMessage_Handler,
+		},
+		{
+			MethodName: "LogoutDevice",
+			Handler:    _WaCoreGateway_LogoutDevice_Handler,
+		},
+		{
+			MethodName: "DeleteDevice",
+			Handler:    _WaCoreGateway_DeleteDevice_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -281,6 +598,16 @@ var WaCoreGateway_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _WaCoreGateway_StreamConnectDevice_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamLiveLocation",
+			Handler:       _WaCoreGateway_StreamLiveLocation_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "UploadMedia",
+			Handler:       _WaCoreGateway_UploadMedia_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "model/proto/wacore.proto",
 }
@@ -14,12 +14,20 @@ const (
 	EventTypeLoggedOut    EventType = "logged_out"
 	EventTypePairSuccess  EventType = "pair_success"
 
+	// Reconnect Events
+	EventTypeReconnecting    EventType = "reconnecting"
+	EventTypeReconnectFailed EventType = "reconnect_failed"
+
 	// Message Events
 	EventTypeInboundMessage  EventType = "inbound_message"
 	EventTypeOutboundMessage EventType = "outbound_message"
+	EventTypeMessageRevoked  EventType = "message_revoked"
+	EventTypeMessageEdited   EventType = "message_edited"
+	EventTypePollVote        EventType = "poll_vote"
 
 	// QR Events
-	EventTypeQR EventType = "qr"
+	EventTypeQR       EventType = "qr"
+	EventTypePairCode EventType = "pair_code"
 
 	// Receipt Events
 	EventTypeReceipt EventType = "receipt"
@@ -47,15 +55,25 @@ const (
 	MessageTypeReaction MessageType = "reaction"
 	MessageTypeButton   MessageType = "button_response"
 	MessageTypeList     MessageType = "list_response"
+	MessageTypePoll     MessageType = "poll"
+	MessageTypeSticker  MessageType = "sticker"
 )
 
 // QueueEvent is the main structure for all events sent to RabbitMQ
 type QueueEvent struct {
-	EventID   string      `json:"event_id"`
-	SenderJID string      `json:"sender_jid"`
-	EventType EventType   `json:"event_type"`
-	Timestamp time.Time   `json:"timestamp"`
-	Data      interface{} `json:"data"`
+	EventID       string      `json:"event_id"`
+	CorrelationID string      `json:"correlation_id,omitempty"` // request ID of the RPC that caused the event
+	SenderJID     string      `json:"sender_jid"`
+	EventType     EventType   `json:"event_type"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Data          interface{} `json:"data"`
+}
+
+// SetCorrelationID sets the correlation ID unless the event already has one
+func (e *QueueEvent) SetCorrelationID(id string) {
+	if e.CorrelationID == "" {
+		e.CorrelationID = id
+	}
 }
 
 // ConnectionEventData represents connection-related events
@@ -64,11 +82,25 @@ type ConnectionEventData struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+// ReconnectEventData represents reconnect supervisor events
+type ReconnectEventData struct {
+	Attempt     int    `json:"attempt"`
+	MaxAttempts int    `json:"max_attempts,omitempty"` // 0 means unlimited
+	NextRetryAt int64  `json:"next_retry_at,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
 // QREventData represents QR code events
 type QREventData struct {
 	Code string `json:"code"`
 }
 
+// PairCodeEventData represents phone number pairing code events
+type PairCodeEventData struct {
+	Code        string `json:"code"`
+	PhoneNumber string `json:"phone_number"`
+}
+
 // MessageEventData represents message events
 type MessageEventData struct {
 	Sender      string                 `json:"sender"`
@@ -78,9 +110,18 @@ type MessageEventData struct {
 	Metadata    map[string]interface{} `json:"metadata"`
 }
 
+// StoredMedia describes inbound media downloaded and persisted by the gateway
+type StoredMedia struct {
+	MediaRef   string `json:"media_ref,omitempty"`
+	LocalPath  string `json:"local_path,omitempty"`
+	SHA256     string `json:"sha256,omitempty"`
+	MediaError string `json:"media_error,omitempty"`
+}
+
 // ImageMessageData represents image message specific data
 type ImageMessageData struct {
 	MessageEventData
+	StoredMedia
 	Caption  string `json:"caption,omitempty"`
 	MimeType string `json:"mime_type,omitempty"`
 	FileSize uint64 `json:"file_size,omitempty"`
@@ -90,6 +131,7 @@ type ImageMessageData struct {
 // AudioMessageData represents audio message specific data
 type AudioMessageData struct {
 	MessageEventData
+	StoredMedia
 	Duration uint32 `json:"duration,omitempty"` // in seconds
 	MimeType string `json:"mime_type,omitempty"`
 	FileSize uint64 `json:"file_size,omitempty"`
@@ -99,6 +141,7 @@ type AudioMessageData struct {
 // VideoMessageData represents video message specific data
 type VideoMessageData struct {
 	MessageEventData
+	StoredMedia
 	Caption  string `json:"caption,omitempty"`
 	Duration uint32 `json:"duration,omitempty"`
 	MimeType string `json:"mime_type,omitempty"`
@@ -109,12 +152,25 @@ type VideoMessageData struct {
 // DocumentMessageData represents document message specific data
 type DocumentMessageData struct {
 	MessageEventData
+	StoredMedia
 	FileName string `json:"file_name,omitempty"`
 	MimeType string `json:"mime_type,omitempty"`
 	FileSize uint64 `json:"file_size,omitempty"`
 	FileURL  string `json:"file_url,omitempty"`
 }
 
+// StickerMessageData represents sticker message specific data
+type StickerMessageData struct {
+	MessageEventData
+	StoredMedia
+	MimeType   string `json:"mime_type,omitempty"`
+	IsAnimated bool   `json:"is_animated"`
+	FileSize   uint64 `json:"file_size,omitempty"`
+	Width      uint32 `json:"width,omitempty"`
+	Height     uint32 `json:"height,omitempty"`
+	FileURL    string `json:"file_url,omitempty"`
+}
+
 // LocationMessageData represents location message specific data
 type LocationMessageData struct {
 	MessageEventData
@@ -147,6 +203,24 @@ type ListResponseMessageData struct {
 	SelectedRowID string `json:"selected_row_id,omitempty"`
 }
 
+// PollMessageData represents poll creation message specific data
+type PollMessageData struct {
+	MessageEventData
+	Question        string   `json:"question"`
+	Options         []string `json:"options"`
+	SelectableCount uint32   `json:"selectable_count"`
+}
+
+// PollVoteEventData represents decrypted poll vote events
+type PollVoteEventData struct {
+	PollID          string   `json:"poll_id"`
+	Chat            string   `json:"chat"`
+	Voter           string   `json:"voter"`
+	SelectedOptions []string `json:"selected_options"`
+	UnknownHashes   []string `json:"unknown_hashes,omitempty"` // hex SHA-256 of options whose poll is not known, e.g. created before a restart
+	Timestamp       int64    `json:"timestamp"`
+}
+
 // ReceiptEventData represents message receipt events
 type ReceiptEventData struct {
 	MessageIDs []string `json:"message_ids"`
@@ -182,6 +256,24 @@ type PairSuccessEventData struct {
 	PhoneNumber string      `json:"phone_number,omitempty"`
 }
 
+// MessageRevokedEventData represents message revoke (delete for everyone) events
+type MessageRevokedEventData struct {
+	MessageID string `json:"message_id"`
+	Chat      string `json:"chat"`
+	RevokedBy string `json:"revoked_by"`
+	FromMe    bool   `json:"from_me"`
+}
+
+// MessageEditedEventData represents message edit events
+type MessageEditedEventData struct {
+	MessageID string      `json:"message_id"`
+	Chat      string      `json:"chat"`
+	EditedBy  string      `json:"edited_by"`
+	FromMe    bool        `json:"from_me"`
+	Text      string      `json:"text,omitempty"`
+	Message   interface{} `json:"message,omitempty"`
+}
+
 // OutboundMessageData represents data for outbound message events
 type OutboundMessageData struct {
 	MessageID   string      `json:"message_id"`
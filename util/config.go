@@ -11,8 +11,55 @@ var Configuration Config
 
 type Config struct {
 	Server struct {
-		Port int `mapstructure:"port"`
+		Port            int `mapstructure:"port"`
+		ShutdownTimeout int `mapstructure:"shutdown_timeout"`
+		TLS             struct {
+			Enabled          bool   `mapstructure:"enabled"`
+			CertFile         string `mapstructure:"cert_file"`
+			KeyFile          string `mapstructure:"key_file"`
+			ClientCAFile     string `mapstructure:"client_ca_file"`
+			ClientIdentities []struct {
+				Identity   string   `mapstructure:"identity"`
+				Tenant     string   `mapstructure:"tenant"`
+				SenderJIDs []string `mapstructure:"sender_jids"`
+			} `mapstructure:"client_identities"`
+		} `mapstructure:"tls"`
 	}
+	Gateway struct {
+		Enabled bool `mapstructure:"enabled"`
+		Port    int  `mapstructure:"port"`
+	} `mapstructure:"gateway"`
+	Auth struct {
+		Enabled bool                `mapstructure:"enabled"`
+		Tenants map[string][]string `mapstructure:"tenants"`
+		APIKeys []struct {
+			Name       string   `mapstructure:"name"`
+			Key        string   `mapstructure:"key"`
+			Tenant     string   `mapstructure:"tenant"`
+			SenderJIDs []string `mapstructure:"sender_jids"`
+		} `mapstructure:"api_keys"`
+		JWT struct {
+			Secret   string `mapstructure:"secret"`
+			Issuer   string `mapstructure:"issuer"`
+			Audience string `mapstructure:"audience"`
+		} `mapstructure:"jwt"`
+	} `mapstructure:"auth"`
+	Health struct {
+		Enabled  bool `mapstructure:"enabled"`
+		Port     int  `mapstructure:"port"`
+		Interval int  `mapstructure:"interval"`
+	} `mapstructure:"health"`
+	Metrics struct {
+		Enabled bool `mapstructure:"enabled"`
+		Port    int  `mapstructure:"port"`
+	} `mapstructure:"metrics"`
+	Tracing struct {
+		Enabled     bool    `mapstructure:"enabled"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		ServiceName string  `mapstructure:"service_name"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+	} `mapstructure:"tracing"`
 	Logger struct {
 		Dir        string `mapstructure:"dir"`
 		FileName   string `mapstructure:"file_name"`
@@ -48,6 +95,44 @@ type Config struct {
 		ReceiptsQueue      string `mapstructure:"receipt_event_queue"`
 		QRHandlerQueue     string `mapstructure:"qr_handler_queue"`
 	} `mapstructure:"queues"`
+	Reconnect struct {
+		InitialBackoff int `mapstructure:"initial_backoff"`
+		MaxBackoff     int `mapstructure:"max_backoff"`
+		MaxAttempts    int `mapstructure:"max_attempts"`
+	} `mapstructure:"reconnect"`
+	Polls struct {
+		CacheTTL int `mapstructure:"cache_ttl"`
+	} `mapstructure:"polls"`
+	Media struct {
+		Source struct {
+			Timeout         int      `mapstructure:"timeout"`
+			MaxSize         int      `mapstructure:"max_size"`
+			BlockPrivateIPs bool     `mapstructure:"block_private_ips"`
+			AllowedDirs     []string `mapstructure:"allowed_dirs"`
+		} `mapstructure:"source"`
+		Download struct {
+			Enabled    bool     `mapstructure:"enabled"`
+			Types      []string `mapstructure:"types"`
+			MaxSize    int      `mapstructure:"max_size"`
+			Concurrent int      `mapstructure:"concurrent"`
+			Timeout    int      `mapstructure:"timeout"`
+		} `mapstructure:"download"`
+		Store struct {
+			Driver string `mapstructure:"driver"`
+			Local  struct {
+				Dir string `mapstructure:"dir"`
+			} `mapstructure:"local"`
+			S3 struct {
+				Endpoint  string `mapstructure:"endpoint"`
+				Region    string `mapstructure:"region"`
+				Bucket    string `mapstructure:"bucket"`
+				Prefix    string `mapstructure:"prefix"`
+				AccessKey string `mapstructure:"access_key"`
+				SecretKey string `mapstructure:"secret_key"`
+				UseSSL    bool   `mapstructure:"use_ssl"`
+			} `mapstructure:"s3"`
+		} `mapstructure:"store"`
+	} `mapstructure:"media"`
 }
 
 // LoadConfig reads configuration from file or environment variables.